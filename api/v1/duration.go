@@ -0,0 +1,149 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is an ORDS duration setting.
+// It accepts either a Go duration string (e.g. 90s, 1h30m) or an ISO-8601 duration (e.g. PT90S, P1DT2H);
+// integers, in nanoseconds, remain accepted for resources created before the Duration type was introduced.
+// +kubebuilder:validation:XIntOrString
+// +kubebuilder:validation:Pattern=`^(0|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+|P([0-9]+W)?([0-9]+D)?(T([0-9]+H)?([0-9]+M)?([0-9]+(\.[0-9]+)?S)?)?)$`
+type Duration struct {
+	time.Duration `json:"-"`
+}
+
+// ISO-8601 durations limited to weeks, days, hours, minutes and (fractional) seconds;
+// years and months have no fixed length
+var iso8601Duration = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDuration parses a Go or ISO-8601 duration string
+func ParseDuration(s string) (Duration, error) {
+	if !strings.HasPrefix(s, "P") {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return Duration{}, err
+		}
+		if d < 0 {
+			return Duration{}, fmt.Errorf("duration %q must not be negative", s)
+		}
+		return Duration{d}, nil
+	}
+
+	match := iso8601Duration.FindStringSubmatch(s)
+	if match == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return Duration{}, fmt.Errorf("invalid ISO-8601 duration %q", s)
+	}
+	var d time.Duration
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute}
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(match[i+1], 10, 64)
+		if err != nil {
+			return Duration{}, fmt.Errorf("invalid ISO-8601 duration %q: %w", s, err)
+		}
+		d += time.Duration(n) * unit
+	}
+	if match[5] != "" {
+		seconds, err := strconv.ParseFloat(match[5], 64)
+		if err != nil {
+			return Duration{}, fmt.Errorf("invalid ISO-8601 duration %q: %w", s, err)
+		}
+		d += time.Duration(seconds * float64(time.Second))
+	}
+	return Duration{d}, nil
+}
+
+// ISO8601 formats the duration as ORDS (java.time.Duration) expects, e.g. PT1H30M
+func (d Duration) ISO8601() string {
+	if d.Duration == 0 {
+		return "PT0S"
+	}
+	remaining := d.Duration
+	hours := remaining / time.Hour
+	remaining -= hours * time.Hour
+	minutes := remaining / time.Minute
+	remaining -= minutes * time.Minute
+
+	var b strings.Builder
+	b.WriteString("PT")
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if remaining > 0 {
+		b.WriteString(strconv.FormatFloat(remaining.Seconds(), 'f', -1, 64) + "S")
+	}
+	return b.String()
+}
+
+// UnmarshalJSON accepts a duration string; integers are accepted as nanoseconds
+// for resources created before the Duration type was introduced
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		var nanoseconds int64
+		if numErr := json.Unmarshal(b, &nanoseconds); numErr != nil {
+			return fmt.Errorf("duration must be a string: %w", err)
+		}
+		d.Duration = time.Duration(nanoseconds)
+		return nil
+	}
+	parsed, err := ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
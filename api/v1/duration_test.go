@@ -0,0 +1,100 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package v1
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Duration", func() {
+	DescribeTable("parsing",
+		func(input string, expected time.Duration) {
+			d, err := ParseDuration(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Duration).To(Equal(expected))
+		},
+		Entry("Go duration", "1h30m", 90*time.Minute),
+		Entry("Go milliseconds", "250ms", 250*time.Millisecond),
+		Entry("ISO-8601 time", "PT1H30M", 90*time.Minute),
+		Entry("ISO-8601 fractional seconds", "PT0.5S", 500*time.Millisecond),
+		Entry("ISO-8601 days", "P1DT2H", 26*time.Hour),
+		Entry("ISO-8601 weeks", "P1W", 7*24*time.Hour),
+	)
+
+	DescribeTable("rejecting",
+		func(input string) {
+			_, err := ParseDuration(input)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty ISO-8601", "P"),
+		Entry("dangling time designator", "PT"),
+		Entry("ISO-8601 months", "P1M"),
+		Entry("negative", "-5s"),
+		Entry("no unit", "15"),
+	)
+
+	DescribeTable("formatting as ISO-8601",
+		func(d time.Duration, expected string) {
+			Expect(Duration{d}.ISO8601()).To(Equal(expected))
+		},
+		Entry("zero", time.Duration(0), "PT0S"),
+		Entry("hours and minutes", 90*time.Minute, "PT1H30M"),
+		Entry("days as hours", 26*time.Hour, "PT26H"),
+		Entry("fractional seconds", 1500*time.Millisecond, "PT1.5S"),
+	)
+
+	It("should round-trip through JSON", func() {
+		var d Duration
+		Expect(json.Unmarshal([]byte(`"PT5M"`), &d)).To(Succeed())
+		Expect(d.Duration).To(Equal(5 * time.Minute))
+
+		b, err := json.Marshal(d)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`"5m0s"`))
+	})
+
+	It("should read legacy nanosecond integers", func() {
+		var d Duration
+		Expect(json.Unmarshal([]byte(`60000000000`), &d)).To(Succeed())
+		Expect(d.Duration).To(Equal(time.Minute))
+	})
+})
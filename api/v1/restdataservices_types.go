@@ -39,10 +39,9 @@
 package v1
 
 import (
-	"time"
-
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // RestDataServicesSpec defines the desired state of RestDataServices
@@ -52,11 +51,21 @@ type RestDataServicesSpec struct {
 	//+kubebuilder:validation:Enum=Deployment;StatefulSet;DaemonSet
 	//+kubebuilder:default=Deployment
 	WorkloadType string `json:"workloadType,omitempty"`
-	// Defines the number of desired Replicas when workloadType is Deployment or StatefulSet
+	// Defines the number of desired Replicas when workloadType is Deployment or StatefulSet;
+	// not applied to the Workload while autoscaling is set
 	//+kubebuilder:validation:Minimum=1
 	//+kubebuilder:default=1
 	Replicas int32 `json:"replicas,omitempty"`
-	// Specifies whether to restart pods when Global or Pool configurations change
+	// Contains the settings of a HorizontalPodAutoscaler scaling the Workload; not created when not set
+	Autoscaling *AutoscalingSettings `json:"autoscaling,omitempty"`
+	// Contains the settings of the revision history of the rendered configuration
+	ConfigRevisions ConfigRevisionSettings `json:"configRevisions,omitempty"`
+	// Contains the settings of the Workload rollouts, such as those restarting pods on configuration changes
+	Rollout RolloutSettings `json:"rollout,omitempty"`
+	// Contains the settings of a PodDisruptionBudget of the Workload pods; when not set,
+	// one allowing a single unavailable pod is created for more than one replica
+	DisruptionBudget *DisruptionBudgetSettings `json:"disruptionBudget,omitempty"`
+	// Specifies whether to restart pods when Global or Pool configurations, or their referenced Secrets, change
 	ForceRestart bool `json:"forceRestart,omitempty"`
 	// Specifies the ORDS container image
 	//+kubecbuilder:default=container-registry.oracle.com/database/ords:latest
@@ -65,8 +74,23 @@ type RestDataServicesSpec struct {
 	//+kubebuilder:validation:Enum=IfNotPresent;Always;Never
 	//+kubebuilder:default=IfNotPresent
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
-	// Specifies the Secret Name for pulling the ORDS container image
-	ImagePullSecrets string `json:"imagePullSecrets,omitempty"`
+	// Specifies the Secrets for pulling the ORDS container image.
+	// A single Secret name string is deprecated but still accepted, and converted to a list.
+	// The schema admits both forms, so the list is validated by the webhook.
+	//+kubebuilder:validation:Schemaless
+	//+kubebuilder:pruning:PreserveUnknownFields
+	ImagePullSecrets ImagePullSecrets `json:"imagePullSecrets,omitempty"`
+	// Specifies a ConfigMap key holding a replacement for the init script run by the init container.
+	// The operator's built-in script is used when not set.
+	InitScript *corev1.ConfigMapKeySelector `json:"initScript,omitempty"`
+	// Contains the scheduling, resources, probes and metadata of the Workload pods
+	PodTemplate PodTemplate `json:"podTemplate,omitempty"`
+	// Contains the settings of the Service exposing the Workload pods
+	Service ServiceSettings `json:"service,omitempty"`
+	// Contains the settings of an Ingress routing to the Service; not created when not set
+	Ingress *IngressSettings `json:"ingress,omitempty"`
+	// Contains the settings of a Gateway API HTTPRoute routing to the Service; not created when not set
+	HTTPRoute *HTTPRouteSettings `json:"httpRoute,omitempty"`
 	// Contains settings that are configured across the entire ORDS instance.
 	GlobalSettings GlobalSettings `json:"globalSettings"`
 	// Contains settings for individual pools/databases
@@ -74,150 +98,334 @@ type RestDataServicesSpec struct {
 	// +k8s:openapi-gen=true
 }
 
+// PodTemplate defines the scheduling, resources, probes and metadata of the Workload pods.
+// The scheduling and resources also apply to the pods of the install/uninstall Jobs.
+type PodTemplate struct {
+	// Specifies labels added to the pods; the operator's selector labels take precedence
+	Labels map[string]string `json:"labels,omitempty"`
+	// Specifies annotations added to the pods
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Specifies the compute resources of the ORDS containers
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Specifies the labels of the nodes the pods can be scheduled on
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Specifies the tolerations of the pods
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Specifies the scheduling constraints of the pods
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// Specifies how the pods are spread across topology domains, such as nodes or zones
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// Specifies the PriorityClass of the pods
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// Specifies the ServiceAccount the pods run as; the namespace default when not set
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Replaces the default readiness probe of the ORDS container, an HTTP(S) GET of the standalone.context.path
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+	// Replaces the default liveness probe of the ORDS container, an HTTP(S) GET of the standalone.context.path
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+	// Replaces the default startup probe of the ORDS container, allowing 10 minutes for the first start
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+}
+
+// ServiceSettings defines the Service exposing the Workload pods
+type ServiceSettings struct {
+	// Specifies the Service type
+	//+kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	//+kubebuilder:default=ClusterIP
+	Type corev1.ServiceType `json:"type,omitempty"`
+	// Specifies annotations added to the Service, such as those of the cloud load balancer
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Specifies labels added to the Service; the operator's labels take precedence
+	Labels map[string]string `json:"labels,omitempty"`
+	// Specifies the client CIDRs allowed to access a LoadBalancer Service
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+	// Specifies how external traffic is routed by a NodePort or LoadBalancer Service
+	//+kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+	// Specifies the session affinity of the Service
+	//+kubebuilder:validation:Enum=None;ClientIP
+	//+kubebuilder:default=None
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+	// Specifies the node ports of a NodePort or LoadBalancer Service; allocated by Kubernetes when not set
+	NodePorts ServiceNodePorts `json:"nodePorts,omitempty"`
+}
+
+// AutoscalingSettings defines the HorizontalPodAutoscaler scaling a Deployment or StatefulSet Workload.
+// Utilization targets are percentages of the resources requested in podTemplate.resources.
+type AutoscalingSettings struct {
+	// Specifies the minimum number of replicas; defaults to replicas
+	//+kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// Specifies the maximum number of replicas
+	//+kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// Specifies the target average CPU utilization; defaults to 80 when no target is set
+	//+kubebuilder:validation:Minimum=1
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// Specifies the target average memory utilization
+	//+kubebuilder:validation:Minimum=1
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// ConfigRevisionSettings defines the revisions of the rendered Global and Pool configuration, kept in immutable ConfigMaps
+type ConfigRevisionSettings struct {
+	// Specifies the number of revisions kept
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=5
+	HistoryLimit int32 `json:"historyLimit,omitempty"`
+	// Specifies the revision, from status.configRevisions, rendered in place of the configuration of the spec until unset;
+	// takes precedence over the oracle.com/ords-operator-rollback-to annotation
+	RollbackTo string `json:"rollbackTo,omitempty"`
+}
+
+// RolloutSettings defines how the Workload replaces its pods.  Settings not applying to the workloadType are rejected;
+// those not set keep the Kubernetes defaults.
+type RolloutSettings struct {
+	// Specifies the number or percentage of pods created above the desired number during a Deployment or DaemonSet rollout
+	//+kubebuilder:validation:XIntOrString
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// Specifies the number or percentage of pods that can be unavailable during a rollout;
+	// StatefulSets require the MaxUnavailableStatefulSet feature gate
+	//+kubebuilder:validation:XIntOrString
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// Specifies the ordinal from which StatefulSet pods are updated; pods with a lower ordinal keep the previous revision
+	//+kubebuilder:validation:Minimum=0
+	Partition *int32 `json:"partition,omitempty"`
+	// Specifies the seconds a new pod must be Ready before it is considered available, allowing its pools to warm up
+	//+kubebuilder:validation:Minimum=0
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+	// Specifies the seconds a Deployment rollout can take to make progress before it is reported as failed
+	//+kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+	// Specifies the number of old revisions kept to allow rolling back the Workload
+	//+kubebuilder:validation:Minimum=0
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+}
+
+// DisruptionBudgetSettings defines the PodDisruptionBudget limiting the voluntary disruptions, such as node drains,
+// of a Deployment or StatefulSet Workload.  Only one of minAvailable and maxUnavailable can be set.
+type DisruptionBudgetSettings struct {
+	// Specifies that no PodDisruptionBudget is created
+	Disabled bool `json:"disabled,omitempty"`
+	// Specifies the number or percentage of pods that must remain available
+	//+kubebuilder:validation:XIntOrString
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// Specifies the number or percentage of pods that can be unavailable; defaults to 1 when minAvailable is not set
+	//+kubebuilder:validation:XIntOrString
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// IngressSettings defines the Ingress routing the standalone.context.path to the Service HTTP port.
+// TLS is terminated by the Ingress controller.
+type IngressSettings struct {
+	// Specifies the IngressClass of the Ingress; the cluster default when not set
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Specifies the host of the Ingress; defaults to standalone.https.host, any host when neither is set
+	Host string `json:"host,omitempty"`
+	// Specifies the Secret holding the TLS certificate of the host, with the keys tls.crt and tls.key;
+	// defaults to the certSecret when it uses those keys
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// Specifies annotations added to the Ingress, such as those of the Ingress controller
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Specifies labels added to the Ingress; the operator's labels take precedence
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// HTTPRouteSettings defines the Gateway API HTTPRoute routing the standalone.context.path to the Service HTTP port.
+// TLS is terminated by the Gateway listeners.
+type HTTPRouteSettings struct {
+	// Specifies the Gateways the HTTPRoute attaches to
+	//+kubebuilder:validation:MinItems=1
+	ParentRefs []HTTPRouteParentRef `json:"parentRefs"`
+	// Specifies the hostnames of the HTTPRoute; defaults to standalone.https.host, any host when neither is set
+	Hostnames []string `json:"hostnames,omitempty"`
+	// Specifies annotations added to the HTTPRoute
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Specifies labels added to the HTTPRoute; the operator's labels take precedence
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// HTTPRouteParentRef references a Gateway listener
+type HTTPRouteParentRef struct {
+	// Specifies the name of the Gateway
+	Name string `json:"name"`
+	// Specifies the namespace of the Gateway; the namespace of the resource when not set
+	Namespace string `json:"namespace,omitempty"`
+	// Specifies the name of the Gateway listener; all listeners when not set
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ServiceNodePorts defines the node port of each Service port
+type ServiceNodePorts struct {
+	// Specifies the node port of the HTTP port
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	HTTP int32 `json:"http,omitempty"`
+	// Specifies the node port of the HTTPS port
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	HTTPS int32 `json:"https,omitempty"`
+	// Specifies the node port of the Mongo port
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	Mongo int32 `json:"mongo,omitempty"`
+}
+
+// GlobalSettings are written to settings.xml; the ords tag of each field names its ORDS setting
 type GlobalSettings struct {
 	// Specifies the setting to enable or disable metadata caching.
-	CacheMetadataEnabled *bool `json:"cache.metadata.enabled,omitempty"`
+	CacheMetadataEnabled *bool `json:"cache.metadata.enabled,omitempty" ords:"cache.metadata.enabled"`
 
 	// Specifies the duration after a GraphQL schema is not accessed from the cache that it expires.
-	CacheMetadataGraphQLExpireAfterAccess *time.Duration `json:"cache.metadata.graphql.expireAfterAccess,omitempty"`
+	CacheMetadataGraphQLExpireAfterAccess *Duration `json:"cache.metadata.graphql.expireAfterAccess,omitempty" ords:"cache.metadata.graphql.expireAfterAccess"`
 
 	// Specifies the duration after a GraphQL schema is cached that it expires and has to be loaded again.
-	CacheMetadataGraphQLExpireAfterWrite *time.Duration `json:"cache.metadata.graphql.expireAfterWrite,omitempty"`
+	CacheMetadataGraphQLExpireAfterWrite *Duration `json:"cache.metadata.graphql.expireAfterWrite,omitempty" ords:"cache.metadata.graphql.expireAfterWrite"`
 
 	// Specifies the setting to determine for how long a metadata record remains in the cache.
 	// Longer duration means, it takes longer to view the applied changes.
 	// The formats accepted are based on the ISO-8601 duration format.
-	CacheMetadataTimeout *time.Duration `json:"cache.metadata.timeout,omitempty"`
+	CacheMetadataTimeout *Duration `json:"cache.metadata.timeout,omitempty" ords:"cache.metadata.timeout"`
 
 	// Specifies the setting to enable or disable JWKS caching.
-	CacheMetadataJWKSEnabled *bool `json:"cache.metadata.jwks.enabled,omitempty"`
+	CacheMetadataJWKSEnabled *bool `json:"cache.metadata.jwks.enabled,omitempty" ords:"cache.metadata.jwks.enabled"`
 
 	// Specifies the initial capacity of the JWKS cache.
-	CacheMetadataJWKSInitialCapacity *int32 `json:"cache.metadata.jwks.initialCapacity,omitempty"`
+	CacheMetadataJWKSInitialCapacity *int32 `json:"cache.metadata.jwks.initialCapacity,omitempty" ords:"cache.metadata.jwks.initialCapacity"`
 
 	// Specifies the maximum capacity of the JWKS cache.
-	CacheMetadataJWKSMaximumSize *int32 `json:"cache.metadata.jwks.maximumSize,omitempty"`
+	CacheMetadataJWKSMaximumSize *int32 `json:"cache.metadata.jwks.maximumSize,omitempty" ords:"cache.metadata.jwks.maximumSize"`
 
 	// Specifies the duration after a JWK is not accessed from the cache that it expires.
 	// By default this is disabled.
-	CacheMetadataJWKSExpireAfterAccess *time.Duration `json:"cache.metadata.jwks.expireAfterAccess,omitempty"`
+	CacheMetadataJWKSExpireAfterAccess *Duration `json:"cache.metadata.jwks.expireAfterAccess,omitempty" ords:"cache.metadata.jwks.expireAfterAccess"`
 
 	// Specifies the duration after a JWK is cached, that is, it expires and has to be loaded again.
-	CacheMetadataJWKSExpireAfterWrite *time.Duration `json:"cache.metadata.jwks.expireAfterWrite,omitempty"`
+	CacheMetadataJWKSExpireAfterWrite *Duration `json:"cache.metadata.jwks.expireAfterWrite,omitempty" ords:"cache.metadata.jwks.expireAfterWrite"`
 
 	// Specifies whether the Database API is enabled.
-	DatabaseAPIEnabled *bool `json:"database.api.enabled,omitempty"`
+	DatabaseAPIEnabled *bool `json:"database.api.enabled,omitempty" ords:"database.api.enabled"`
 
 	// Specifies to disable the Database API administration related services.
 	// Only applicable when Database API is enabled.
-	DatabaseAPIManagementServicesDisabled *bool `json:"database.api.management.services.disabled,omitempty"`
+	DatabaseAPIManagementServicesDisabled *bool `json:"database.api.management.services.disabled,omitempty" ords:"database.api.management.services.disabled"`
 
 	// Specifies how long to wait before retrying an invalid pool.
-	DBInvalidPoolTimeout *time.Duration `json:"db.invalidPoolTimeout,omitempty"`
+	DBInvalidPoolTimeout *Duration `json:"db.invalidPoolTimeout,omitempty" ords:"db.invalidPoolTimeout"`
 
 	// Specifies the maximum join nesting depth limit for GraphQL queries.
-	FeatureGraphQLMaxNestingDepth *int32 `json:"feature.grahpql.max.nesting.depth,omitempty"`
+	FeatureGraphQLMaxNestingDepth *int32 `json:"feature.grahpql.max.nesting.depth,omitempty" ords:"feature.graphql.max.nesting.depth"`
 
 	// Specifies the name of the HTTP request header that uniquely identifies the request end to end as
 	// it passes through the various layers of the application stack.
 	// In Oracle this header is commonly referred to as the ECID (Entity Context ID).
-	RequestTraceHeaderName string `json:"request.traceHeaderName,omitempty"`
+	RequestTraceHeaderName string `json:"request.traceHeaderName,omitempty" ords:"request.traceHeaderName"`
 
 	// Specifies the maximum number of unsuccessful password attempts allowed.
 	// Enabled by setting a positive integer value.
-	SecurityCredentialsAttempts *int32 `json:"security.credentials.attempts,omitempty"`
+	SecurityCredentialsAttempts *int32 `json:"security.credentials.attempts,omitempty" ords:"security.credentials.attempts"`
 
 	// Specifies the period to lock the account that has exceeded maximum attempts.
-	SecurityCredentialsLockTime *time.Duration `json:"security.credentials.lock.time,omitempty"`
+	SecurityCredentialsLockTime *Duration `json:"security.credentials.lock.time,omitempty" ords:"security.credentials.lock.time"`
 
 	// Specifies the HTTP listen port.
 	//+kubebuilder:default:=8080
-	StandaloneHTTPPort *int32 `json:"standalone.http.port,omitempty"`
+	StandaloneHTTPPort *int32 `json:"standalone.http.port,omitempty" ords:"standalone.http.port"`
 
 	// Specifies the SSL certificate hostname.
-	StandaloneHTTPSHost string `json:"standalone.https.host,omitempty"`
+	StandaloneHTTPSHost string `json:"standalone.https.host,omitempty" ords:"standalone.https.host"`
 
 	// Specifies the HTTPS listen port.
 	//+kubebuilder:default:=8443
-	StandaloneHTTPSPort *int32 `json:"standalone.https.port,omitempty"`
+	StandaloneHTTPSPort *int32 `json:"standalone.https.port,omitempty" ords:"standalone.https.port"`
 
 	// Specifies the period for Standalone Mode to wait until it is gracefully shutdown.
-	StandaloneStopTimeout *time.Duration `json:"standalone.stop.timeout,omitempty"`
+	StandaloneStopTimeout *Duration `json:"standalone.stop.timeout,omitempty" ords:"standalone.stop.timeout"`
 
 	// Specifies whether to display error messages on the browser.
-	DebugPrintDebugToScreen *bool `json:"debug.printDebugToScreen,omitempty"`
+	DebugPrintDebugToScreen *bool `json:"debug.printDebugToScreen,omitempty" ords:"debug.printDebugToScreen"`
 
 	// Specifies how the HTTP error responses must be formatted.
 	// html - Force all responses to be in HTML format
 	// json - Force all responses to be in JSON format
 	// auto - Automatically determines most appropriate format for the request (default).
-	ErrorResponseFormat string `json:"error.responseFormat,omitempty"`
+	ErrorResponseFormat string `json:"error.responseFormat,omitempty" ords:"error.responseFormat"`
 
 	// Specifies the Internet Content Adaptation Protocol (ICAP) port to virus scan files.
 	// Either icap.port or icap.secure.port are required to have a value.
-	ICAPPort *int32 `json:"icap.port,omitempty"`
+	ICAPPort *int32 `json:"icap.port,omitempty" ords:"icap.port"`
 
 	// Specifies the Internet Content Adaptation Protocol (ICAP) port to virus scan files.
 	// Either icap.port or icap.secure.port are required to have a value.
 	// If values for both icap.port and icap.secure.port are provided, then the value of icap.port is ignored.
-	ICAPSecurePort *int32 `json:"icap.secure.port,omitempty"`
+	ICAPSecurePort *int32 `json:"icap.secure.port,omitempty" ords:"icap.secure.port"`
 
 	// Specifies the Internet Content Adaptation Protocol (ICAP) server name or IP address to virus scan files.
 	// The icap.server is required to have a value.
-	ICAPServer string `json:"icap.server,omitempty"`
+	ICAPServer string `json:"icap.server,omitempty" ords:"icap.server"`
 
 	// Specifies whether procedures are to be logged.
-	LogProcedure bool `json:"log.procedure,omitempty"`
+	LogProcedure bool `json:"log.procedure,omitempty" ords:"log.procedure"`
 
 	// Specifies to enable the API for MongoDB.
 	//+kubebuider:default=false
-	MongoEnabled bool `json:"mongo.enabled,omitempty"`
+	MongoEnabled bool `json:"mongo.enabled,omitempty" ords:"mongo.enabled"`
 
 	// Specifies the API for MongoDB listen port.
 	//+kubebuilder:default:=27017
-	MongoPort *int32 `json:"mongo.port,omitempty"`
+	MongoPort *int32 `json:"mongo.port,omitempty" ords:"mongo.port"`
 
 	// Specifies the maximum idle time for a Mongo connection in milliseconds.
-	MongoIdleTimeout *time.Duration `json:"mongo.idle.timeout,omitempty"`
+	MongoIdleTimeout *Duration `json:"mongo.idle.timeout,omitempty" ords:"mongo.idle.timeout,milliseconds"`
 
 	// Specifies the maximum time for a Mongo database operation in milliseconds.
-	MongoOpTimeout *time.Duration `json:"mongo.op.timeout,omitempty"`
+	MongoOpTimeout *Duration `json:"mongo.op.timeout,omitempty" ords:"mongo.op.timeout,milliseconds"`
 
 	// If this value is set to true, then the Oracle REST Data Services internal exclusion list is not enforced.
 	// Oracle recommends that you do not set this value to true.
-	SecurityDisableDefaultExclusionList *bool `json:"security.disableDefaultExclusionList,omitempty"`
+	SecurityDisableDefaultExclusionList *bool `json:"security.disableDefaultExclusionList,omitempty" ords:"security.disableDefaultExclusionList"`
 
 	// Specifies a pattern for procedures, packages, or schema names which are forbidden to be directly executed from a browser.
-	SecurityExclusionList string `json:"security.exclusionList,omitempty"`
+	SecurityExclusionList string `json:"security.exclusionList,omitempty" ords:"security.exclusionList"`
 
 	// Specifies a pattern for procedures, packages, or schema names which are allowed to be directly executed from a browser.
-	SecurityInclusionList string `json:"security.inclusionList,omitempty"`
+	SecurityInclusionList string `json:"security.inclusionList,omitempty" ords:"security.inclusionList"`
 
 	// Specifies the maximum number of cached procedure validations.
 	// Set this value to 0 to force the validation procedure to be invoked on each request.
-	SecurityMaxEntries *int32 `json:"security.maxEntries,omitempty"`
+	SecurityMaxEntries *int32 `json:"security.maxEntries,omitempty" ords:"security.maxEntries"`
 
 	// Specifies whether HTTPS is available in your environment.
-	SecurityVerifySSL *bool `json:"security.verifySSL,omitempty"`
+	SecurityVerifySSL *bool `json:"security.verifySSL,omitempty" ords:"security.verifySSL"`
 
 	// Specifies the context path where ords is located.
 	//+kubebuilder:default:="/ords"
-	StandaloneContextPath string `json:"standalone.context.path,omitempty"`
+	StandaloneContextPath string `json:"standalone.context.path,omitempty" ords:"standalone.context.path"`
 
 	/*************************************************
 	* Undocumented
 	/************************************************/
 
 	// Specifies that the HTTP Header contains the specified text
-	// Usually set to 'X-Forwarded-Proto: https' coming from a load-balancer
-	SecurityHTTPSHeaderCheck string `json:"security.httpsHeaderCheck,omitempty"`
+	// Usually set to 'X-Forwarded-Proto: https' coming from a load-balancer;
+	// defaults to it when an ingress or httpRoute is set
+	SecurityHTTPSHeaderCheck string `json:"security.httpsHeaderCheck,omitempty" ords:"security.httpsHeaderCheck"`
 
 	// Specifies to force HTTPS; this is set to default to false as in real-world TLS should
 	// terminiate at the LoadBalancer
-	SecurityForceHTTPS bool `json:"security.forceHTTPS,omitempty"`
+	SecurityForceHTTPS bool `json:"security.forceHTTPS,omitempty" ords:"security.forceHTTPS"`
 
 	// Specifies to trust Access from originating domains
-	SecuirtyExternalSessionTrustedOrigins string `json:"security.externalSessionTrustedOrigins,omitempty"`
+	SecuirtyExternalSessionTrustedOrigins string `json:"security.externalSessionTrustedOrigins,omitempty" ords:"security.externalSessionTrustedOrigins"`
+
+	/*************************************************
+	* Additional
+	/************************************************/
+
+	// Specifies additional ORDS settings, by key, to be written to settings.xml.
+	// Use for settings without a dedicated field; settings with a dedicated field and settings
+	// managed by the operator (file locations and passwords) are not permitted.
+	AdditionalSettings map[string]string `json:"additionalSettings,omitempty" ords:"-"`
 
 	/*************************************************
 	* Customised
@@ -234,12 +442,12 @@ type GlobalSettings struct {
 	// Specifies if HTTP request access logs should be enabled
 	// If enabled, logs will be written to /opt/oracle/sa/log/global
 	//+kubebuilder:default:=false
-	EnableStandaloneAccessLog bool `json:"enable.standalone.access.log,omitempty"`
+	EnableStandaloneAccessLog bool `json:"enable.standalone.access.log,omitempty" ords:"-"`
 
 	// Specifies if HTTP request access logs should be enabled
 	// If enabled, logs will be written to /opt/oracle/sa/log/global
 	//+kubebuilder:default:=false
-	EnableMongoAccessLog bool `json:"enable.mongo.access.log,omitempty"`
+	EnableMongoAccessLog bool `json:"enable.mongo.access.log,omitempty" ords:"-"`
 
 	/*
 		//Specifies the SSL certificate path.
@@ -255,7 +463,7 @@ type GlobalSettings struct {
 
 	// Specifies the Secret containing the SSL Certificates
 	// Replaces: standalone.https.cert and standalone.https.cert.key
-	CertSecret *CertificateSecret `json:"certSecret,omitempty"`
+	CertSecret *CertificateSecret `json:"certSecret,omitempty" ords:"-"`
 
 	/*************************************************
 	* Disabled
@@ -300,28 +508,47 @@ type GlobalSettings struct {
 	// HARDCODED to global/logs
 }
 
+// PoolSettings are written to pool.xml; the ords tag of each field names its ORDS setting
 type PoolSettings struct {
 	// Specifies the Pool Name
-	PoolName string `json:"poolName"`
+	PoolName string `json:"poolName" ords:"-"`
 
 	// Specify whether to perform ORDS installation/upgrades automatically
 	// The db.adminUser and db.adminUser.secret must be set, otherwise setting is ignored
+	// The upgradePolicy specifies when installation/upgrades are performed
 	// This setting will be ignored for ADB
 	//+kubebuilder:default:=false
-	AutoUpgradeORDS bool `json:"autoUpgradeORDS,omitempty"`
+	AutoUpgradeORDS bool `json:"autoUpgradeORDS,omitempty" ords:"-"`
 
 	// Specify whether to perform APEX installation/upgrades automatically
 	// The db.adminUser and db.adminUser.secret must be set, otherwise setting is ignored
+	// The upgradePolicy specifies when installation/upgrades are performed
 	// This setting will be ignored for ADB
 	//+kubebuilder:default:=false
-	AutoUpgradeAPEX bool `json:"autoUpgradeAPEX,omitempty"`
+	AutoUpgradeAPEX bool `json:"autoUpgradeAPEX,omitempty" ords:"-"`
+
+	// Specifies when the installation/upgrade of the pool's database is performed for a new image
+	// Automatic installs/upgrades as soon as the image changes
+	// Manual waits for the oracle.com/ords-operator-approve-upgrade annotation of the resource to be set to the new version
+	// Never does not install/upgrade the database
+	//+kubebuilder:validation:Enum=Automatic;Manual;Never
+	//+kubebuilder:default:=Automatic
+	UpgradePolicy string `json:"upgradePolicy,omitempty" ords:"-"`
+
+	// Specifies what happens in the database when the pool is removed or the resource is deleted
+	// Retain leaves the database untouched
+	// Uninstall runs ords uninstall or, for ADB, drops the db.username runtime user created by the operator
+	// Uninstall removes ORDS from the database for all its users; the db.adminUser and db.adminUser.secret must be set
+	//+kubebuilder:validation:Enum=Retain;Uninstall
+	//+kubebuilder:default:=Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty" ords:"-"`
 
 	// Specifies the name of the database user for the connection.
 	// For non-ADB this will default to ORDS_PUBLIC_USER
 	// For ADBs this must be specified and not ORDS_PUBLIC_USER
 	// If ORDS_PUBLIC_USER is specified for an ADB, the workload will fail
 	//+kubebuilder:default:="ORDS_PUBLIC_USER"
-	DBUsername string `json:"db.username,omitempty"`
+	DBUsername string `json:"db.username,omitempty" ords:"db.username"`
 
 	// Specifies the password of the specified database user.
 	// Replaced by: DBSecret PasswordSecret `json:"dbSecret"`
@@ -329,10 +556,10 @@ type PoolSettings struct {
 
 	// Specifies the Secret with the dbUsername and dbPassword values
 	// for the connection.
-	DBSecret PasswordSecret `json:"db.secret"`
+	DBSecret PasswordSecret `json:"db.secret" ords:"-"`
 
 	// Specifies the username for the database account that ORDS uses for administration operations in the database.
-	DBAdminUser string `json:"db.adminUser,omitempty"`
+	DBAdminUser string `json:"db.adminUser,omitempty" ords:"db.adminUser"`
 
 	// Specifies the password for the database account that ORDS uses for administration operations in the database.
 	// Replaced by: DBAdminUserSecret PasswordSecret `json:"dbAdminUserSecret,omitempty"`
@@ -341,10 +568,10 @@ type PoolSettings struct {
 	// Specifies the Secret with the dbAdminUser (SYS) and dbAdminPassword values
 	// for the database account that ORDS uses for administration operations in the database.
 	// replaces: db.adminUser.password
-	DBAdminUserSecret PasswordSecret `json:"db.adminUser.secret,omitempty"`
+	DBAdminUserSecret PasswordSecret `json:"db.adminUser.secret,omitempty" ords:"-"`
 
 	// Specifies the username for the database account that ORDS uses for the Pluggable Database Lifecycle Management.
-	DBCDBAdminUser string `json:"db.cdb.adminUser,omitempty"`
+	DBCDBAdminUser string `json:"db.cdb.adminUser,omitempty" ords:"db.cdb.adminUser"`
 
 	// Specifies the password for the database account that ORDS uses for the Pluggable Database Lifecycle Management.
 	// Replaced by: DBCdbAdminUserSecret PasswordSecret `json:"dbCdbAdminUserSecret,omitempty"`
@@ -353,190 +580,199 @@ type PoolSettings struct {
 	// Specifies the Secret with the dbCdbAdminUser (SYS) and dbCdbAdminPassword values
 	// Specifies the username for the database account that ORDS uses for the Pluggable Database Lifecycle Management.
 	// Replaces: db.cdb.adminUser.password
-	DBCDBAdminUserSecret PasswordSecret `json:"db.cdb.adminUser.secret,omitempty"`
+	DBCDBAdminUserSecret PasswordSecret `json:"db.cdb.adminUser.secret,omitempty" ords:"-"`
 
 	// Specifies the comma delimited list of additional roles to assign authenticated APEX administrator type users.
-	ApexSecurityAdministratorRoles string `json:"apex.security.administrator.roles,omitempty"`
+	ApexSecurityAdministratorRoles string `json:"apex.security.administrator.roles,omitempty" ords:"apex.security.administrator.roles"`
 
 	// Specifies the comma delimited list of additional roles to assign authenticated regular APEX users.
-	ApexSecurityUserRoles string `json:"apex.security.user.roles,omitempty"`
+	ApexSecurityUserRoles string `json:"apex.security.user.roles,omitempty" ords:"apex.security.user.roles"`
 
 	// Specifies the source for database credentials when creating a direct connection for running SQL statements.
 	// Value can be one of pool or request.
 	// If the value is pool, then the credentials defined in this pool is used to create a JDBC connection.
 	// If the value request is used, then the credentials in the request is used to create a JDBC connection and if successful, grants the requestor SQL Developer role.
 	//+kubebuilder:validation:Enum=pool;request
-	DBCredentialsSource string `json:"db.credentialsSource,omitempty"`
+	DBCredentialsSource string `json:"db.credentialsSource,omitempty" ords:"db.credentialsSource"`
 
 	// Indicates how long to wait to gracefully destroy a pool before moving to forcefully destroy all connections including borrowed ones.
-	DBPoolDestroyTimeout *time.Duration `json:"db.poolDestroyTimeout,omitempty"`
+	DBPoolDestroyTimeout *Duration `json:"db.poolDestroyTimeout,omitempty" ords:"db.poolDestroyTimeout"`
 
 	// Specifies to enable tracking of JDBC resources.
 	// If not released causes in resource leaks or exhaustion in the database.
 	// Tracking imposes a performance overhead.
-	DebugTrackResources *bool `json:"debug.trackResources,omitempty"`
+	DebugTrackResources *bool `json:"debug.trackResources,omitempty" ords:"debug.trackResources"`
 
 	// Specifies to disable the Open Service Broker services available for the pool.
-	FeatureOpenservicebrokerExclude *bool `json:"feature.openservicebroker.exclude,omitempty"`
+	FeatureOpenservicebrokerExclude *bool `json:"feature.openservicebroker.exclude,omitempty" ords:"feature.openservicebroker.exclude"`
 
 	// Specifies to enable the Database Actions feature.
-	FeatureSDW *bool `json:"feature.sdw,omitempty"`
+	FeatureSDW *bool `json:"feature.sdw,omitempty" ords:"feature.sdw"`
 
 	// Specifies a comma separated list of HTTP Cookies to exclude when initializing an Oracle Web Agent environment.
-	HttpCookieFilter string `json:"http.cookie.filter,omitempty"`
+	HttpCookieFilter string `json:"http.cookie.filter,omitempty" ords:"http.cookie.filter"`
 
 	// Identifies the database role that indicates that the database user must get the SQL Administrator role.
-	JDBCAuthAdminRole string `json:"jdbc.auth.admin.role,omitempty"`
+	JDBCAuthAdminRole string `json:"jdbc.auth.admin.role,omitempty" ords:"jdbc.auth.admin.role"`
 
 	// Specifies how a pooled JDBC connection and corresponding database session, is released when a request has been processed.
-	JDBCCleanupMode string `json:"jdbc.cleanup.mode,omitempty"`
+	JDBCCleanupMode string `json:"jdbc.cleanup.mode,omitempty" ords:"jdbc.cleanup.mode"`
 
 	// If it is true, then it causes a trace of the SQL statements performed by Oracle Web Agent to be echoed to the log.
-	OwaTraceSql *bool `json:"owa.trace.sql,omitempty"`
+	OwaTraceSql *bool `json:"owa.trace.sql,omitempty" ords:"owa.trace.sql"`
 
 	// Indicates if the PL/SQL Gateway functionality should be available for a pool or not.
 	// Value can be one of disabled, direct, or proxied.
 	// If the value is direct, then the pool serves the PL/SQL Gateway requests directly.
 	// If the value is proxied, the PLSQL_GATEWAY_CONFIG view is used to determine the user to whom to proxy.
 	//+kubebuilder:validation:Enum=disabled;direct;proxied
-	PlsqlGatewayMode string `json:"plsql.gateway.mode,omitempty"`
+	PlsqlGatewayMode string `json:"plsql.gateway.mode,omitempty" ords:"plsql.gateway.mode"`
 
 	// Specifies whether the JWT Profile authentication is available. Supported values:
-	SecurityJWTProfileEnabled *bool `json:"security.jwt.profile.enabled,omitempty"`
+	SecurityJWTProfileEnabled *bool `json:"security.jwt.profile.enabled,omitempty" ords:"security.jwt.profile.enabled"`
 
 	// Specifies the maximum number of bytes read from the JWK url.
-	SecurityJWKSSize *int32 `json:"security.jwks.size,omitempty"`
+	SecurityJWKSSize *int32 `json:"security.jwks.size,omitempty" ords:"security.jwks.size"`
 
 	// Specifies the maximum amount of time before timing-out when accessing a JWK url.
-	SecurityJWKSConnectionTimeout *time.Duration `json:"security.jwks.connection.timeout,omitempty"`
+	SecurityJWKSConnectionTimeout *Duration `json:"security.jwks.connection.timeout,omitempty" ords:"security.jwks.connection.timeout"`
 
 	// Specifies the maximum amount of time reading a response from the JWK url before timing-out.
-	SecurityJWKSReadTimeout *time.Duration `json:"security.jwks.read.timeout,omitempty"`
+	SecurityJWKSReadTimeout *Duration `json:"security.jwks.read.timeout,omitempty" ords:"security.jwks.read.timeout"`
 
 	// Specifies the minimum interval between refreshing the JWK cached value.
-	SecurityJWKSRefreshInterval *time.Duration `json:"security.jwks.refresh.interval,omitempty"`
+	SecurityJWKSRefreshInterval *Duration `json:"security.jwks.refresh.interval,omitempty" ords:"security.jwks.refresh.interval"`
 
 	// Specifies the maximum skew the JWT time claims are accepted.
 	// This is useful if the clock on the JWT issuer and ORDS differs by a few seconds.
-	SecurityJWTAllowedSkew *time.Duration `json:"security.jwt.allowed.skew,omitempty"`
+	SecurityJWTAllowedSkew *Duration `json:"security.jwt.allowed.skew,omitempty" ords:"security.jwt.allowed.skew,seconds"`
 
 	// Specifies the maximum allowed age of a JWT in seconds, regardless of expired claim.
 	// The age of the JWT is taken from the JWT issued at claim.
-	SecurityJWTAllowedAge *time.Duration `json:"security.jwt.allowed.age,omitempty"`
+	SecurityJWTAllowedAge *Duration `json:"security.jwt.allowed.age,omitempty" ords:"security.jwt.allowed.age,seconds"`
 
 	// Indicates the type of security.requestValidationFunction: javascript or plsql.
 	//+kubebuilder:validation:Enum=plsql;javascript
-	SecurityValidationFunctionType string `json:"security.validationFunctionType,omitempty"`
+	SecurityValidationFunctionType string `json:"security.validationFunctionType,omitempty" ords:"security.validationFunctionType"`
 
 	// The type of connection.
 	//+kubebuilder:validation:Enum=basic;tns;customurl
-	DBConnectionType string `json:"db.connectionType,omitempty"`
+	DBConnectionType string `json:"db.connectionType,omitempty" ords:"db.connectionType"`
 
 	// Specifies the JDBC URL connection to connect to the database.
-	DBCustomURL string `json:"db.customURL,omitempty"`
+	DBCustomURL string `json:"db.customURL,omitempty" ords:"db.customURL"`
 
 	// Specifies the host system for the Oracle database.
-	DBHostname string `json:"db.hostname,omitempty"`
+	DBHostname string `json:"db.hostname,omitempty" ords:"db.hostname"`
 
 	// Specifies the database listener port.
-	DBPort *int32 `json:"db.port,omitempty"`
+	DBPort *int32 `json:"db.port,omitempty" ords:"db.port"`
 
 	// Specifies the network service name of the database.
-	DBServicename string `json:"db.servicename,omitempty"`
+	DBServicename string `json:"db.servicename,omitempty" ords:"db.servicename"`
 
 	// Specifies the name of the database.
-	DBSid string `json:"db.sid,omitempty"`
+	DBSid string `json:"db.sid,omitempty" ords:"db.sid"`
 
 	// Specifies the TNS alias name that matches the name in the tnsnames.ora file.
-	DBTnsAliasName string `json:"db.tnsAliasName,omitempty"`
+	DBTnsAliasName string `json:"db.tnsAliasName,omitempty" ords:"db.tnsAliasName"`
 
 	// Specifies the service name in the wallet archive for the pool.
-	DBWalletZipService string `json:"db.wallet.zip.service,omitempty"`
+	DBWalletZipService string `json:"db.wallet.zip.service,omitempty" ords:"-"`
 
 	// Specifies the JDBC driver type.
 	//+kubebuilder:validation:Enum=thin;oci8
-	JDBCDriverType string `json:"jdbc.DriverType,omitempty"`
+	JDBCDriverType string `json:"jdbc.DriverType,omitempty" ords:"jdbc.DriverType"`
 
 	// Specifies how long an available connection can remain idle before it is closed. The inactivity connection timeout is in seconds.
-	JDBCInactivityTimeout *int32 `json:"jdbc.InactivityTimeout,omitempty"`
+	JDBCInactivityTimeout *int32 `json:"jdbc.InactivityTimeout,omitempty" ords:"jdbc.InactivityTimeout"`
 
 	// Specifies the initial size for the number of connections that will be created.
 	// The default is low, and should probably be set higher in most production environments.
-	JDBCInitialLimit *int32 `json:"jdbc.InitialLimit,omitempty"`
+	JDBCInitialLimit *int32 `json:"jdbc.InitialLimit,omitempty" ords:"jdbc.InitialLimit"`
 
 	// Specifies the maximum number of times to reuse a connection before it is discarded and replaced with a new connection.
-	JDBCMaxConnectionReuseCount *int32 `json:"jdbc.MaxConnectionReuseCount,omitempty"`
+	JDBCMaxConnectionReuseCount *int32 `json:"jdbc.MaxConnectionReuseCount,omitempty" ords:"jdbc.MaxConnectionReuseCount"`
 
 	// Sets the maximum connection reuse time property.
-	JDBCMaxConnectionReuseTime *int32 `json:"jdbc.MaxConnectionReuseTime,omitempty"`
+	JDBCMaxConnectionReuseTime *int32 `json:"jdbc.MaxConnectionReuseTime,omitempty" ords:"jdbc.MaxConnectionReuseTime"`
 
 	// Sets the time in seconds to trust an idle connection to skip a validation test.
-	JDBCSecondsToTrustIdleConnection *int32 `json:"jdbc.SecondsToTrustIdleConnection,omitempty"`
+	JDBCSecondsToTrustIdleConnection *int32 `json:"jdbc.SecondsToTrustIdleConnection,omitempty" ords:"jdbc.SecondsToTrustIdleConnection"`
 
 	// Specifies the maximum number of connections.
 	// Might be too low for some production environments.
-	JDBCMaxLimit *int32 `json:"jdbc.MaxLimit,omitempty"`
+	JDBCMaxLimit *int32 `json:"jdbc.MaxLimit,omitempty" ords:"jdbc.MaxLimit"`
 
 	// Specifies if the PL/SQL Gateway calls can be authenticated using database users.
 	// If the value is true then this feature is enabled. If the value is false, then this feature is disabled.
 	// Oracle recommends not to use this feature.
 	// This feature used only to facilitate customers migrating from mod_plsql.
-	JDBCAuthEnabled *bool `json:"jdbc.auth.enabled,omitempty"`
+	JDBCAuthEnabled *bool `json:"jdbc.auth.enabled,omitempty" ords:"jdbc.auth.enabled"`
 
 	// Specifies the maximum number of statements to cache for each connection.
-	JDBCMaxStatementsLimit *int32 `json:"jdbc.MaxStatementsLimit,omitempty"`
+	JDBCMaxStatementsLimit *int32 `json:"jdbc.MaxStatementsLimit,omitempty" ords:"jdbc.MaxStatementsLimit"`
 
 	// Specifies the minimum number of connections.
-	JDBCMinLimit *int32 `json:"jdbc.MinLimit,omitempty"`
+	JDBCMinLimit *int32 `json:"jdbc.MinLimit,omitempty" ords:"jdbc.MinLimit"`
 
 	// Specifies a timeout period on a statement.
 	// An abnormally long running query or script, executed by a request, may leave it in a hanging state unless a timeout is
 	// set on the statement. Setting a timeout on the statement ensures that all the queries automatically timeout if
 	// they are not completed within the specified time period.
-	JDBCStatementTimeout *int32 `json:"jdbc.statementTimeout,omitempty"`
+	JDBCStatementTimeout *int32 `json:"jdbc.statementTimeout,omitempty" ords:"jdbc.statementTimeout"`
 
 	// Specifies the default page to display. The Oracle REST Data Services Landing Page.
-	MiscDefaultPage string `json:"misc.defaultPage,omitempty"`
+	MiscDefaultPage string `json:"misc.defaultPage,omitempty" ords:"misc.defaultPage"`
 
 	// Specifies the maximum number of rows that will be returned from a query when processing a RESTful service
 	// and that will be returned from a nested cursor in a result set.
 	// Affects all RESTful services generated through a SQL query, regardless of whether the resource is paginated.
-	MiscPaginationMaxRows *int32 `json:"misc.pagination.maxRows,omitempty"`
+	MiscPaginationMaxRows *int32 `json:"misc.pagination.maxRows,omitempty" ords:"misc.pagination.maxRows"`
 
 	// Specifies the procedure name(s) to execute after executing the procedure specified on the URL.
 	// Multiple procedure names must be separated by commas.
-	ProcedurePostProcess string `json:"procedurePostProcess,omitempty"`
+	ProcedurePostProcess string `json:"procedurePostProcess,omitempty" ords:"procedure.postProcess"`
 
 	// Specifies the procedure name(s) to execute prior to executing the procedure specified on the URL.
 	// Multiple procedure names must be separated by commas.
-	ProcedurePreProcess string `json:"procedure.preProcess,omitempty"`
+	ProcedurePreProcess string `json:"procedure.preProcess,omitempty" ords:"procedure.preProcess"`
 
 	// Specifies the function to be invoked prior to dispatching each Oracle REST Data Services based REST Service.
 	// The function can perform configuration of the database session, perform additional validation or authorization of the request.
 	// If the function returns true, then processing of the request continues.
 	// If the function returns false, then processing of the request is aborted and an HTTP 403 Forbidden status is returned.
-	ProcedureRestPreHook string `json:"procedure.rest.preHook,omitempty"`
+	ProcedureRestPreHook string `json:"procedure.rest.preHook,omitempty" ords:"procedure.rest.preHook"`
 
 	// Specifies an authentication function to determine if the requested procedure in the URL should be allowed or disallowed for processing.
 	// The function should return true if the procedure is allowed; otherwise, it should return false.
 	// If it returns false, Oracle REST Data Services will return WWW-Authenticate in the response header.
-	SecurityRequestAuthenticationFunction string `json:"security.requestAuthenticationFunction,omitempty"`
+	SecurityRequestAuthenticationFunction string `json:"security.requestAuthenticationFunction,omitempty" ords:"security.requestAuthenticationFunction"`
 
 	// Specifies a validation function to determine if the requested procedure in the URL should be allowed or disallowed for processing.
 	// The function should return true if the procedure is allowed; otherwise, return false.
 	//+kubebuilder:default:="ords_util.authorize_plsql_gateway"
-	SecurityRequestValidationFunction string `json:"security.requestValidationFunction,omitempty"`
+	SecurityRequestValidationFunction string `json:"security.requestValidationFunction,omitempty" ords:"security.requestValidationFunction"`
 
 	// When using the SODA REST API, specifies the default number of documents returned for a GET request on a collection when a
 	// limit is not specified in the URL. Must be a positive integer, or "unlimited" for no limit.
-	SODADefaultLimit string `json:"soda.defaultLimit,omitempty"`
+	SODADefaultLimit string `json:"soda.defaultLimit,omitempty" ords:"soda.defaultLimit"`
 
 	// When using the SODA REST API, specifies the maximum number of documents that will be returned for a GET request on a collection URL,
 	// regardless of any limit specified in the URL. Must be a positive integer, or "unlimited" for no limit.
-	SODAMaxLimit string `json:"soda.maxLimit,omitempty"`
+	SODAMaxLimit string `json:"soda.maxLimit,omitempty" ords:"soda.maxLimit"`
 
 	// Specifies whether the REST-Enabled SQL service is active.
-	RestEnabledSqlActive *bool `json:"restEnabledSql.active,omitempty"`
+	RestEnabledSqlActive *bool `json:"restEnabledSql.active,omitempty" ords:"restEnabledSql.active"`
+
+	/*************************************************
+	* Additional
+	/************************************************/
+
+	// Specifies additional ORDS settings, by key, to be written to pool.xml.
+	// Use for settings without a dedicated field; settings with a dedicated field and settings
+	// managed by the operator (file locations and passwords) are not permitted.
+	AdditionalSettings map[string]string `json:"additionalSettings,omitempty" ords:"-"`
 
 	/*************************************************
 	* Customised
@@ -555,7 +791,7 @@ type PoolSettings struct {
 
 	// Specifies the Secret containing the wallet archive containing connection details for the pool.
 	// Replaces: db.wallet.zip
-	DBWalletSecret *DBWalletSecret `json:"dbWalletSecret,omitempty"`
+	DBWalletSecret *DBWalletSecret `json:"dbWalletSecret,omitempty" ords:"-"`
 
 	/*
 		// The directory location of your tnsnames.ora file.
@@ -565,7 +801,7 @@ type PoolSettings struct {
 
 	// Specifies the Secret containing the TNS_ADMIN directory
 	// Replaces: db.tnsDirectory
-	TNSAdminSecret *TNSAdminSecret `json:"tnsAdminSecret,omitempty"`
+	TNSAdminSecret *TNSAdminSecret `json:"tnsAdminSecret,omitempty" ords:"-"`
 
 	/*************************************************
 	* Disabled
@@ -632,7 +868,11 @@ type RestDataServicesStatus struct {
 	Status string `json:"status,omitempty"`
 	// Indicates the current Workload type of the resource
 	WorkloadType string `json:"workloadType,omitempty"`
-	// Indicates the ORDS version
+	// Indicates the number of Workload pods, for the scale subresource
+	Replicas int32 `json:"replicas,omitempty"`
+	// Indicates the label selector of the Workload pods, for the scale subresource
+	Selector string `json:"selector,omitempty"`
+	// Indicates the ORDS version of the running pods, as reported by their init container
 	ORDSVersion string `json:"ordsVersion,omitempty"`
 	// Indicates the HTTP port of the resource exposed by the pods
 	HTTPPort *int32 `json:"httpPort,omitempty"`
@@ -642,15 +882,69 @@ type RestDataServicesStatus struct {
 	MongoPort int32 `json:"mongoPort,omitempty"`
 	// Indicates if the resource is out-of-sync with the configuration
 	RestartRequired bool `json:"restartRequired"`
+	// Indicates the hash of the rendered configuration and referenced Secrets
+	ConfigHash string `json:"configHash,omitempty"`
+	// Indicates the hash of the configuration the Workload pods were started with
+	WorkloadConfigHash string `json:"workloadConfigHash,omitempty"`
+
+	// Indicates the revisions of the rendered configuration, newest first
+	//+listType=map
+	//+listMapKey=name
+	ConfigRevisions []ConfigRevision `json:"configRevisions,omitempty"`
+	// Indicates the revision of the rendered configuration
+	CurrentConfigRevision string `json:"currentConfigRevision,omitempty"`
+
+	// Indicates the observed state of each pool
+	//+listType=map
+	//+listMapKey=poolName
+	Pools []PoolStatus `json:"pools,omitempty"`
 
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
 
+// ConfigRevision defines a revision of the rendered configuration
+type ConfigRevision struct {
+	// Indicates the name of the revision ConfigMap, to roll back to
+	Name string `json:"name"`
+	// Indicates the number of the revision
+	Revision int64 `json:"revision"`
+	// Indicates the hash of the rendered configuration of the revision
+	ConfigHash string `json:"configHash,omitempty"`
+	// Indicates when the revision was recorded
+	CreationTimestamp metav1.Time `json:"creationTimestamp,omitempty"`
+}
+
+// PoolStatus defines the observed state of a pool, as reported by its last install Job
+type PoolStatus struct {
+	// Indicates the name of the pool
+	PoolName string `json:"poolName"`
+	// Indicates the type of database, ADB or Oracle
+	DBType string `json:"dbType,omitempty"`
+	// Indicates the ORDS schema version installed in the database
+	ORDSVersion string `json:"ordsVersion,omitempty"`
+	// Indicates the APEX schema version installed in the database
+	APEXVersion string `json:"apexVersion,omitempty"`
+	// Indicates the last actions performed, by the install Job or the init container of the Workload pods
+	LastAction string `json:"lastAction,omitempty"`
+	// Indicates the result of the last install/upgrade, Succeeded or Failed
+	LastActionResult string `json:"lastActionResult,omitempty"`
+	// Indicates the error of the last install/upgrade, if any
+	LastActionError string `json:"lastActionError,omitempty"`
+	// Indicates when the last actions finished
+	LastActionTime *metav1.Time `json:"lastActionTime,omitempty"`
+	// Indicates when the database connectivity was last checked
+	LastConnectionCheck *metav1.Time `json:"lastConnectionCheck,omitempty"`
+	// Indicates the error of the last connectivity check, if any
+	ConnectionError string `json:"connectionError,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 //+kubebuilder:printcolumn:JSONPath=".status.status",name="status",type="string"
 //+kubebuilder:printcolumn:JSONPath=".status.workloadType",name="workloadType",type="string"
+//+kubebuilder:printcolumn:JSONPath=".status.replicas",name="replicas",type="integer"
 //+kubebuilder:printcolumn:JSONPath=".status.ordsVersion",name="ordsVersion",type="string"
 //+kubebuilder:printcolumn:JSONPath=".status.httpPort",name="httpPort",type="integer"
 //+kubebuilder:printcolumn:JSONPath=".status.httpsPort",name="httpsPort",type="integer"
@@ -0,0 +1,500 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package v1
+
+import (
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var restdataserviceslog = logf.Log.WithName("restdataservices-resource")
+
+// Defaults applied when the CRD (OpenAPI) defaulting has been skipped
+const (
+	defaultWorkloadType                      = "Deployment"
+	defaultReplicas                          = int32(1)
+	defaultImagePullPolicy                   = corev1.PullIfNotPresent
+	defaultServiceType                       = corev1.ServiceTypeClusterIP
+	defaultServiceSessionAffinity            = corev1.ServiceAffinityNone
+	defaultTargetCPUUtilizationPercentage    = int32(80)
+	defaultConfigRevisionHistoryLimit        = int32(5)
+	defaultStandaloneHTTPPort                = int32(8080)
+	defaultStandaloneHTTPSPort               = int32(8443)
+	defaultMongoPort                         = int32(27017)
+	defaultStandaloneContextPath             = "/ords"
+	defaultDBUsername                        = "ORDS_PUBLIC_USER"
+	defaultPasswordKey                       = "password"
+	defaultSecurityRequestValidationFunction = "ords_util.authorize_plsql_gateway"
+	defaultDeletionPolicy                    = DeletionPolicyRetain
+	defaultUpgradePolicy                     = UpgradePolicyAutomatic
+)
+
+// Pool deletion policies
+const (
+	DeletionPolicyRetain    = "Retain"
+	DeletionPolicyUninstall = "Uninstall"
+)
+
+// Pool upgrade policies
+const (
+	UpgradePolicyAutomatic = "Automatic"
+	UpgradePolicyManual    = "Manual"
+	UpgradePolicyNever     = "Never"
+)
+
+// Settings the operator renders itself; these are file locations inside the container
+// or passwords held in Secrets and cannot be set using additionalSettings
+var operatorManagedSettings = map[string]bool{
+	"standalone.doc.root":       true,
+	"standalone.access.log":     true,
+	"standalone.https.cert":     true,
+	"standalone.https.cert.key": true,
+	"standalone.static.path":    true,
+	"mongo.access.log":          true,
+	"security.credentials.file": true,
+	"error.externalPath":        true,
+	"db.password":               true,
+	"db.adminUser.password":     true,
+	"db.cdb.adminUser.password": true,
+	"db.tnsDirectory":           true,
+	"db.wallet.zip":             true,
+	"db.wallet.zip.path":        true,
+}
+
+// ORDS setting keys are dotted identifiers, i.e. security.jwt.allowed.skew
+var settingKeyRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*(\.[A-Za-z0-9_-]+)*$`)
+
+// IsOperatorManagedSetting reports whether an ORDS setting is managed by the operator
+func IsOperatorManagedSetting(key string) bool {
+	return operatorManagedSettings[key]
+}
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *RestDataServices) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-database-oracle-com-v1-restdataservices,mutating=true,failurePolicy=fail,sideEffects=None,groups=database.oracle.com,resources=restdataservices,verbs=create;update,versions=v1,name=mrestdataservices.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &RestDataServices{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *RestDataServices) Default() {
+	restdataserviceslog.Info("default", "name", r.Name)
+	r.Spec.SetDefaults()
+}
+
+// SetDefaults fills in every defaulted field of the spec that has not been set.
+// It is shared by the defaulting webhook and the controller so that both work from the same spec.
+func (s *RestDataServicesSpec) SetDefaults() {
+	if s.WorkloadType == "" {
+		s.WorkloadType = defaultWorkloadType
+	}
+	if s.Replicas == 0 {
+		s.Replicas = defaultReplicas
+	}
+	if s.Autoscaling != nil {
+		if s.Autoscaling.MinReplicas == nil {
+			s.Autoscaling.MinReplicas = &[]int32{s.Replicas}[0]
+		}
+		if s.Autoscaling.TargetCPUUtilizationPercentage == nil && s.Autoscaling.TargetMemoryUtilizationPercentage == nil {
+			s.Autoscaling.TargetCPUUtilizationPercentage = &[]int32{defaultTargetCPUUtilizationPercentage}[0]
+		}
+	}
+	if s.ImagePullPolicy == "" {
+		s.ImagePullPolicy = defaultImagePullPolicy
+	}
+	if s.ConfigRevisions.HistoryLimit == 0 {
+		s.ConfigRevisions.HistoryLimit = defaultConfigRevisionHistoryLimit
+	}
+	if s.Service.Type == "" {
+		s.Service.Type = defaultServiceType
+	}
+	if s.Service.SessionAffinity == "" {
+		s.Service.SessionAffinity = defaultServiceSessionAffinity
+	}
+	s.GlobalSettings.SetDefaults()
+	for _, pool := range s.PoolSettings {
+		if pool != nil {
+			pool.SetDefaults()
+		}
+	}
+}
+
+// SetDefaults fills in the defaulted GlobalSettings
+func (g *GlobalSettings) SetDefaults() {
+	if g.StandaloneHTTPPort == nil {
+		g.StandaloneHTTPPort = &[]int32{defaultStandaloneHTTPPort}[0]
+	}
+	if g.StandaloneHTTPSPort == nil {
+		g.StandaloneHTTPSPort = &[]int32{defaultStandaloneHTTPSPort}[0]
+	}
+	if g.MongoPort == nil {
+		g.MongoPort = &[]int32{defaultMongoPort}[0]
+	}
+	if g.StandaloneContextPath == "" {
+		g.StandaloneContextPath = defaultStandaloneContextPath
+	}
+}
+
+// SetDefaults fills in the defaulted PoolSettings
+func (p *PoolSettings) SetDefaults() {
+	if p.DBUsername == "" {
+		p.DBUsername = defaultDBUsername
+	}
+	if p.SecurityRequestValidationFunction == "" {
+		p.SecurityRequestValidationFunction = defaultSecurityRequestValidationFunction
+	}
+	if p.DeletionPolicy == "" {
+		p.DeletionPolicy = defaultDeletionPolicy
+	}
+	if p.UpgradePolicy == "" {
+		p.UpgradePolicy = defaultUpgradePolicy
+	}
+	for _, secret := range []*PasswordSecret{&p.DBSecret, &p.DBAdminUserSecret, &p.DBCDBAdminUserSecret} {
+		if secret.PasswordKey == "" {
+			secret.PasswordKey = defaultPasswordKey
+		}
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-database-oracle-com-v1-restdataservices,mutating=false,failurePolicy=fail,sideEffects=None,groups=database.oracle.com,resources=restdataservices,verbs=create;update,versions=v1,name=vrestdataservices.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &RestDataServices{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *RestDataServices) ValidateCreate() (admission.Warnings, error) {
+	restdataserviceslog.Info("validate create", "name", r.Name)
+	return r.warnings(), r.validateRestDataServices()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *RestDataServices) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	restdataserviceslog.Info("validate update", "name", r.Name)
+	return r.warnings(), r.validateRestDataServices()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *RestDataServices) ValidateDelete() (admission.Warnings, error) {
+	// Nothing to validate on deletion
+	return nil, nil
+}
+
+func (r *RestDataServices) validateRestDataServices() error {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateDurations(field.NewPath("spec").Child("globalSettings"), &r.Spec.GlobalSettings)...)
+	allErrs = append(allErrs, validateAdditionalSettings(field.NewPath("spec").Child("globalSettings").Child("additionalSettings"),
+		r.Spec.GlobalSettings.AdditionalSettings, &r.Spec.GlobalSettings)...)
+	allErrs = append(allErrs, r.Spec.ImagePullSecrets.validate(field.NewPath("spec").Child("imagePullSecrets"))...)
+	allErrs = append(allErrs, r.validatePoolSettings()...)
+	allErrs = append(allErrs, r.Spec.Service.validate(field.NewPath("spec").Child("service"))...)
+	allErrs = append(allErrs, r.Spec.Rollout.validate(field.NewPath("spec").Child("rollout"), r.Spec.WorkloadType)...)
+	if r.Spec.Autoscaling != nil {
+		allErrs = append(allErrs, r.Spec.Autoscaling.validate(field.NewPath("spec").Child("autoscaling"), r.Spec.WorkloadType)...)
+	}
+	if r.Spec.DisruptionBudget != nil {
+		allErrs = append(allErrs, r.Spec.DisruptionBudget.validate(field.NewPath("spec").Child("disruptionBudget"), r.Spec.WorkloadType)...)
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("RestDataServices").GroupKind(), r.Name, allErrs)
+}
+
+func (r *RestDataServices) validatePoolSettings() field.ErrorList {
+	var allErrs field.ErrorList
+	poolsPath := field.NewPath("spec").Child("poolSettings")
+
+	// The controller derives resource and environment variable names from the poolName;
+	// record which pool claimed each derived name to report collisions.
+	definedConfigMaps := make(map[string]string)
+	definedEnvVars := make(map[string]string)
+	for i, pool := range r.Spec.PoolSettings {
+		poolPath := poolsPath.Index(i)
+		if pool == nil {
+			allErrs = append(allErrs, field.Required(poolPath, "pool settings must not be null"))
+			continue
+		}
+		namePath := poolPath.Child("poolName")
+		if pool.PoolName == "" {
+			allErrs = append(allErrs, field.Required(namePath, "poolName must be specified"))
+			continue
+		}
+
+		configMapName := r.Name + "-settings-" + strings.ToLower(pool.PoolName)
+		for _, msg := range validation.IsDNS1123Subdomain(configMapName) {
+			allErrs = append(allErrs, field.Invalid(namePath, pool.PoolName, "derived ConfigMap name "+configMapName+" is invalid: "+msg))
+		}
+		if other, exists := definedConfigMaps[configMapName]; exists {
+			allErrs = append(allErrs, field.Duplicate(namePath, pool.PoolName+" (conflicts with "+other+")"))
+		} else {
+			definedConfigMaps[configMapName] = pool.PoolName
+		}
+
+		envVarName := poolEnvName(pool.PoolName) + "_dbsecret"
+		if other, exists := definedEnvVars[envVarName]; exists && !strings.EqualFold(other, pool.PoolName) {
+			allErrs = append(allErrs, field.Invalid(namePath, pool.PoolName,
+				"conflicts with poolName "+other+"; both map to the init container variable "+envVarName))
+		} else if !exists {
+			definedEnvVars[envVarName] = pool.PoolName
+		}
+
+		allErrs = append(allErrs, pool.validateConnection(poolPath)...)
+		if pool.DeletionPolicy == DeletionPolicyUninstall && (pool.DBAdminUser == "" || pool.DBAdminUserSecret.SecretName == "") {
+			allErrs = append(allErrs, field.Invalid(poolPath.Child("deletionPolicy"), pool.DeletionPolicy,
+				"db.adminUser and db.adminUser.secret are required to uninstall"))
+		}
+		allErrs = append(allErrs, validateAdditionalSettings(poolPath.Child("additionalSettings"), pool.AdditionalSettings, pool)...)
+		allErrs = append(allErrs, validateDurations(poolPath, pool)...)
+	}
+	return allErrs
+}
+
+// validateConnection checks that the settings required by the db.connectionType are present
+func (p *PoolSettings) validateConnection(poolPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	switch p.DBConnectionType {
+	case "basic":
+		if p.DBHostname == "" {
+			allErrs = append(allErrs, field.Required(poolPath.Child("db.hostname"), "required when db.connectionType is basic"))
+		}
+		if p.DBServicename == "" && p.DBSid == "" {
+			allErrs = append(allErrs, field.Required(poolPath.Child("db.servicename"), "db.servicename or db.sid is required when db.connectionType is basic"))
+		}
+	case "tns":
+		if p.TNSAdminSecret == nil || p.TNSAdminSecret.SecretName == "" {
+			allErrs = append(allErrs, field.Required(poolPath.Child("tnsAdminSecret"), "required when db.connectionType is tns"))
+		}
+		if p.DBTnsAliasName == "" {
+			allErrs = append(allErrs, field.Required(poolPath.Child("db.tnsAliasName"), "required when db.connectionType is tns"))
+		}
+	case "customurl":
+		if p.DBCustomURL == "" {
+			allErrs = append(allErrs, field.Required(poolPath.Child("db.customURL"), "required when db.connectionType is customurl"))
+		}
+	}
+	return allErrs
+}
+
+// validate checks the Secret references; the CRD schema does not, as it also admits a legacy Secret name
+func (s ImagePullSecrets) validate(secretsPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, secret := range s {
+		namePath := secretsPath.Index(i).Child("name")
+		if secret.Name == "" {
+			allErrs = append(allErrs, field.Required(namePath, "Secret name must be specified"))
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(secret.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, secret.Name, msg))
+		}
+	}
+	return allErrs
+}
+
+// validate checks that the settings apply to the Service type
+func (s *ServiceSettings) validate(servicePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	external := s.Type == corev1.ServiceTypeNodePort || s.Type == corev1.ServiceTypeLoadBalancer
+	if !external && s.ExternalTrafficPolicy != "" {
+		allErrs = append(allErrs, field.Invalid(servicePath.Child("externalTrafficPolicy"), s.ExternalTrafficPolicy,
+			"only applies to NodePort and LoadBalancer Services"))
+	}
+	if !external && s.NodePorts != (ServiceNodePorts{}) {
+		allErrs = append(allErrs, field.Invalid(servicePath.Child("nodePorts"), s.NodePorts,
+			"only applies to NodePort and LoadBalancer Services"))
+	}
+	for i, sourceRange := range s.LoadBalancerSourceRanges {
+		if s.Type != corev1.ServiceTypeLoadBalancer {
+			allErrs = append(allErrs, field.Invalid(servicePath.Child("loadBalancerSourceRanges"), s.LoadBalancerSourceRanges,
+				"only applies to LoadBalancer Services"))
+			break
+		}
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(sourceRange)); err != nil {
+			allErrs = append(allErrs, field.Invalid(servicePath.Child("loadBalancerSourceRanges").Index(i), sourceRange,
+				"must be a CIDR, e.g. 10.0.0.0/8"))
+		}
+	}
+	return allErrs
+}
+
+func (a *AutoscalingSettings) validate(autoscalingPath *field.Path, workloadType string) field.ErrorList {
+	var allErrs field.ErrorList
+	if workloadType == "DaemonSet" {
+		allErrs = append(allErrs, field.Invalid(autoscalingPath, workloadType, "only applies to Deployment and StatefulSet Workloads"))
+	}
+	if a.MinReplicas != nil && a.MaxReplicas < *a.MinReplicas {
+		allErrs = append(allErrs, field.Invalid(autoscalingPath.Child("maxReplicas"), a.MaxReplicas,
+			"must be greater than or equal to minReplicas"))
+	}
+	return allErrs
+}
+
+func (s *RolloutSettings) validate(rolloutPath *field.Path, workloadType string) field.ErrorList {
+	var allErrs field.ErrorList
+	if s.MaxSurge != nil && workloadType == "StatefulSet" {
+		allErrs = append(allErrs, field.Invalid(rolloutPath.Child("maxSurge"), s.MaxSurge.String(),
+			"only applies to Deployment and DaemonSet Workloads"))
+	}
+	if s.Partition != nil && workloadType != "StatefulSet" {
+		allErrs = append(allErrs, field.Invalid(rolloutPath.Child("partition"), *s.Partition,
+			"only applies to StatefulSet Workloads"))
+	}
+	if s.ProgressDeadlineSeconds != nil {
+		if workloadType != "Deployment" {
+			allErrs = append(allErrs, field.Invalid(rolloutPath.Child("progressDeadlineSeconds"), *s.ProgressDeadlineSeconds,
+				"only applies to Deployment Workloads"))
+		} else if *s.ProgressDeadlineSeconds <= s.MinReadySeconds {
+			allErrs = append(allErrs, field.Invalid(rolloutPath.Child("progressDeadlineSeconds"), *s.ProgressDeadlineSeconds,
+				"must be greater than minReadySeconds"))
+		}
+	}
+	zero := intstr.FromInt32(0)
+	if s.MaxSurge != nil && s.MaxUnavailable != nil &&
+		(*s.MaxSurge == zero || s.MaxSurge.StrVal == "0%") && (*s.MaxUnavailable == zero || s.MaxUnavailable.StrVal == "0%") {
+		allErrs = append(allErrs, field.Invalid(rolloutPath.Child("maxUnavailable"), s.MaxUnavailable.String(),
+			"cannot be 0 when maxSurge is 0"))
+	}
+	return allErrs
+}
+
+func (d *DisruptionBudgetSettings) validate(disruptionBudgetPath *field.Path, workloadType string) field.ErrorList {
+	var allErrs field.ErrorList
+	if d.Disabled {
+		return allErrs
+	}
+	if workloadType == "DaemonSet" {
+		allErrs = append(allErrs, field.Invalid(disruptionBudgetPath, workloadType, "only applies to Deployment and StatefulSet Workloads"))
+	}
+	if d.MinAvailable != nil && d.MaxUnavailable != nil {
+		allErrs = append(allErrs, field.Invalid(disruptionBudgetPath.Child("maxUnavailable"), d.MaxUnavailable.String(),
+			"cannot be set with minAvailable"))
+	}
+	return allErrs
+}
+
+// warnings returns the admission warnings of settings that are accepted but unlikely to work as intended
+func (r *RestDataServices) warnings() admission.Warnings {
+	var warnings admission.Warnings
+	if a := r.Spec.Autoscaling; a != nil {
+		requests := r.Spec.PodTemplate.Resources.Requests
+		if _, ok := requests[corev1.ResourceCPU]; !ok && a.TargetCPUUtilizationPercentage != nil {
+			warnings = append(warnings, "spec.autoscaling: CPU utilization requires spec.podTemplate.resources.requests.cpu")
+		}
+		if _, ok := requests[corev1.ResourceMemory]; !ok && a.TargetMemoryUtilizationPercentage != nil {
+			warnings = append(warnings, "spec.autoscaling: memory utilization requires spec.podTemplate.resources.requests.memory")
+		}
+	}
+	if d := r.Spec.DisruptionBudget; d != nil && !d.Disabled && d.MinAvailable != nil &&
+		d.MinAvailable.Type == intstr.Int && d.MinAvailable.IntVal >= r.Spec.Replicas && r.Spec.Autoscaling == nil {
+		warnings = append(warnings, "spec.disruptionBudget: minAvailable is not lower than replicas, node drains will be blocked")
+	}
+	return warnings
+}
+
+// validateDurations rejects negative durations of the settings struct; the schema pattern does not
+// apply to the legacy nanosecond integers and ORDS cannot parse a negative duration
+func validateDurations(path *field.Path, settings interface{}) field.ErrorList {
+	var allErrs field.ErrorList
+	v := reflect.ValueOf(settings).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		d, ok := v.Field(i).Interface().(*Duration)
+		if !ok || d == nil || d.Duration >= 0 {
+			continue
+		}
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		allErrs = append(allErrs, field.Invalid(path.Child(name), d.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
+
+// validateAdditionalSettings checks the keys of additionalSettings are valid, not managed by the operator
+// and have no typed field in the settings struct
+func validateAdditionalSettings(path *field.Path, settings map[string]string, typed interface{}) field.ErrorList {
+	var allErrs field.ErrorList
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !settingKeyRegexp.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), key, "must be a valid ORDS setting name"))
+		} else if IsOperatorManagedSetting(key) {
+			allErrs = append(allErrs, field.Forbidden(path.Key(key), "setting is managed by the operator"))
+		} else if hasTypedSetting(typed, key) {
+			allErrs = append(allErrs, field.Forbidden(path.Key(key), "setting has a dedicated field"))
+		}
+	}
+	return allErrs
+}
+
+// hasTypedSetting reports whether a field of the settings struct renders the ORDS setting, per its ords tag
+func hasTypedSetting(settings interface{}, key string) bool {
+	t := reflect.TypeOf(settings).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("ords"), ","); name == key {
+			return true
+		}
+	}
+	return false
+}
+
+// poolEnvName returns the prefix of the init container variables for a pool
+// Must be kept in step with envDefine in the controller
+func poolEnvName(poolName string) string {
+	return strings.ReplaceAll(strings.ToLower(poolName), "-", "_")
+}
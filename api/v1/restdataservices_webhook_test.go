@@ -0,0 +1,412 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package v1
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("RestDataServices Webhook", func() {
+	var ords *RestDataServices
+
+	BeforeEach(func() {
+		ords = &RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-resource",
+				Namespace: "default",
+			},
+			Spec: RestDataServicesSpec{
+				Image: "container-registry.oracle.com/database/ords:24.1.1",
+				PoolSettings: []*PoolSettings{{
+					PoolName:         "default",
+					DBConnectionType: "customurl",
+					DBCustomURL:      "jdbc:oracle:thin:@//localhost:1521/FREEPDB1",
+					DBSecret:         PasswordSecret{SecretName: "db-auth"},
+				}},
+			},
+		}
+	})
+
+	causes := func(err error) []metav1.StatusCause {
+		statusErr, ok := err.(*apierrors.StatusError)
+		Expect(ok).To(BeTrue())
+		return statusErr.ErrStatus.Details.Causes
+	}
+
+	Context("When defaulting", func() {
+		It("should fill in every defaulted field", func() {
+			ords.Default()
+
+			Expect(ords.Spec.WorkloadType).To(Equal("Deployment"))
+			Expect(ords.Spec.Replicas).To(Equal(int32(1)))
+			Expect(*ords.Spec.GlobalSettings.StandaloneHTTPPort).To(Equal(int32(8080)))
+			Expect(*ords.Spec.GlobalSettings.StandaloneHTTPSPort).To(Equal(int32(8443)))
+			Expect(*ords.Spec.GlobalSettings.MongoPort).To(Equal(int32(27017)))
+			Expect(ords.Spec.GlobalSettings.StandaloneContextPath).To(Equal("/ords"))
+
+			pool := ords.Spec.PoolSettings[0]
+			Expect(pool.DBUsername).To(Equal("ORDS_PUBLIC_USER"))
+			Expect(pool.DBSecret.PasswordKey).To(Equal("password"))
+			Expect(pool.DBAdminUserSecret.PasswordKey).To(Equal("password"))
+			Expect(pool.SecurityRequestValidationFunction).To(Equal("ords_util.authorize_plsql_gateway"))
+			Expect(pool.DeletionPolicy).To(Equal("Retain"))
+			Expect(pool.UpgradePolicy).To(Equal("Automatic"))
+			Expect(ords.Spec.Service.Type).To(Equal(corev1.ServiceTypeClusterIP))
+			Expect(ords.Spec.Service.SessionAffinity).To(Equal(corev1.ServiceAffinityNone))
+			Expect(ords.Spec.ConfigRevisions.HistoryLimit).To(Equal(int32(5)))
+		})
+
+		It("should not override values that are set", func() {
+			ords.Spec.GlobalSettings.StandaloneHTTPPort = &[]int32{80}[0]
+			ords.Spec.PoolSettings[0].DBSecret.PasswordKey = "secret"
+			ords.Default()
+
+			Expect(*ords.Spec.GlobalSettings.StandaloneHTTPPort).To(Equal(int32(80)))
+			Expect(ords.Spec.PoolSettings[0].DBSecret.PasswordKey).To(Equal("secret"))
+		})
+	})
+
+	Context("When validating pool settings", func() {
+		It("should admit a valid resource", func() {
+			_, err := ords.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject duplicate pool names", func() {
+			duplicate := *ords.Spec.PoolSettings[0]
+			duplicate.PoolName = "DEFAULT"
+			ords.Spec.PoolSettings = append(ords.Spec.PoolSettings, &duplicate)
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ContainElement(HaveField("Field", "spec.poolSettings[1].poolName")))
+		})
+
+		It("should reject pool names that map to the same init container variable", func() {
+			ords.Spec.PoolSettings[0].PoolName = "a-b"
+			collision := *ords.Spec.PoolSettings[0]
+			collision.PoolName = "a_b"
+			ords.Spec.PoolSettings = append(ords.Spec.PoolSettings, &collision)
+
+			_, err := ords.ValidateCreate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("a_b_dbsecret"))
+		})
+
+		It("should require db.hostname for basic connections", func() {
+			ords.Spec.PoolSettings[0].DBConnectionType = "basic"
+			ords.Spec.PoolSettings[0].DBServicename = "FREEPDB1"
+
+			_, err := ords.ValidateUpdate(ords.DeepCopy())
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(HaveField("Field", "spec.poolSettings[0].db.hostname")))
+		})
+
+		It("should require tnsAdminSecret for tns connections", func() {
+			ords.Spec.PoolSettings[0].DBConnectionType = "tns"
+			ords.Spec.PoolSettings[0].DBTnsAliasName = "FREEPDB1"
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(HaveField("Field", "spec.poolSettings[0].tnsAdminSecret")))
+		})
+
+		It("should require the admin user to uninstall", func() {
+			ords.Spec.PoolSettings[0].DeletionPolicy = DeletionPolicyUninstall
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(HaveField("Field", "spec.poolSettings[0].deletionPolicy")))
+
+			ords.Spec.PoolSettings[0].DBAdminUser = "SYS"
+			ords.Spec.PoolSettings[0].DBAdminUserSecret = PasswordSecret{SecretName: "db-admin-auth"}
+			_, err = ords.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When validating durations", func() {
+		It("should reject negative legacy durations", func() {
+			ords.Spec.GlobalSettings.StandaloneStopTimeout = &Duration{-time.Second}
+			ords.Spec.PoolSettings[0].DBPoolDestroyTimeout = &Duration{time.Second}
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(HaveField("Field", "spec.globalSettings.standalone.stop.timeout")))
+		})
+
+		It("should update a resource stored with legacy integer durations", func() {
+			stored := &RestDataServices{}
+			Expect(json.Unmarshal([]byte(`{
+				"metadata": {"name": "test-resource", "namespace": "default"},
+				"spec": {
+					"image": "container-registry.oracle.com/database/ords:24.1.1",
+					"globalSettings": {"standalone.stop.timeout": 10000000000},
+					"poolSettings": [{
+						"poolName": "default",
+						"db.connectionType": "customurl",
+						"db.customURL": "jdbc:oracle:thin:@//localhost:1521/FREEPDB1",
+						"db.secret": {"secretName": "db-auth"},
+						"db.poolDestroyTimeout": 300000000000
+					}]
+				}
+			}`), stored)).To(Succeed())
+			Expect(stored.Spec.GlobalSettings.StandaloneStopTimeout.Duration).To(Equal(10 * time.Second))
+			Expect(stored.Spec.PoolSettings[0].DBPoolDestroyTimeout.Duration).To(Equal(5 * time.Minute))
+
+			updated := stored.DeepCopy()
+			updated.Spec.Replicas = 2
+			_, err := updated.ValidateUpdate(stored)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When validating image pull secrets", func() {
+		It("should reject invalid Secret references", func() {
+			ords.Spec.ImagePullSecrets = ImagePullSecrets{{Name: "registry-auth"}, {}, {Name: "Registry_Auth"}}
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(
+				HaveField("Field", "spec.imagePullSecrets[1].name"),
+				HaveField("Field", "spec.imagePullSecrets[2].name"),
+			))
+		})
+	})
+
+	Context("When validating additional settings", func() {
+		It("should admit settings without a typed field", func() {
+			ords.Spec.GlobalSettings.AdditionalSettings = map[string]string{"standalone.static.context.path": "/i"}
+			ords.Spec.PoolSettings[0].AdditionalSettings = map[string]string{"db.serviceNameSuffix": ".example.com"}
+
+			_, err := ords.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject settings managed by the operator", func() {
+			ords.Spec.GlobalSettings.AdditionalSettings = map[string]string{"standalone.doc.root": "/tmp"}
+			ords.Spec.PoolSettings[0].AdditionalSettings = map[string]string{"db.password": "secret"}
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(
+				HaveField("Field", "spec.globalSettings.additionalSettings[standalone.doc.root]"),
+				HaveField("Field", "spec.poolSettings[0].additionalSettings[db.password]"),
+			))
+		})
+
+		It("should reject settings with a typed field", func() {
+			ords.Spec.GlobalSettings.AdditionalSettings = map[string]string{"standalone.http.port": "8081"}
+			ords.Spec.PoolSettings[0].AdditionalSettings = map[string]string{"db.poolDestroyTimeout": "PT10S"}
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(
+				HaveField("Field", "spec.globalSettings.additionalSettings[standalone.http.port]"),
+				HaveField("Field", "spec.poolSettings[0].additionalSettings[db.poolDestroyTimeout]"),
+			))
+		})
+
+		It("should reject invalid setting names", func() {
+			ords.Spec.GlobalSettings.AdditionalSettings = map[string]string{"bad key": "value"}
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(HaveField("Field", "spec.globalSettings.additionalSettings[bad key]")))
+		})
+	})
+
+	Context("When validating service settings", func() {
+		It("should admit external settings for a LoadBalancer", func() {
+			ords.Spec.Service = ServiceSettings{
+				Type:                     corev1.ServiceTypeLoadBalancer,
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+				ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+				NodePorts:                ServiceNodePorts{HTTP: 30080},
+			}
+
+			_, err := ords.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject external settings for a ClusterIP", func() {
+			ords.Spec.Service = ServiceSettings{
+				Type:                     corev1.ServiceTypeClusterIP,
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+				ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+				NodePorts:                ServiceNodePorts{HTTP: 30080},
+			}
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(
+				HaveField("Field", "spec.service.externalTrafficPolicy"),
+				HaveField("Field", "spec.service.nodePorts"),
+				HaveField("Field", "spec.service.loadBalancerSourceRanges"),
+			))
+		})
+
+		It("should reject invalid source ranges", func() {
+			ords.Spec.Service = ServiceSettings{
+				Type:                     corev1.ServiceTypeLoadBalancer,
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8", "10.0.0.1"},
+			}
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(HaveField("Field", "spec.service.loadBalancerSourceRanges[1]")))
+		})
+	})
+	Context("When validating autoscaling settings", func() {
+		It("should default the minimum to replicas and target the CPU", func() {
+			ords.Spec.Replicas = 2
+			ords.Spec.Autoscaling = &AutoscalingSettings{MaxReplicas: 4}
+			ords.Default()
+
+			Expect(*ords.Spec.Autoscaling.MinReplicas).To(Equal(int32(2)))
+			Expect(*ords.Spec.Autoscaling.TargetCPUUtilizationPercentage).To(Equal(int32(80)))
+			Expect(ords.Spec.Autoscaling.TargetMemoryUtilizationPercentage).To(BeNil())
+		})
+
+		It("should warn when the targeted resources are not requested", func() {
+			ords.Spec.Autoscaling = &AutoscalingSettings{MaxReplicas: 4}
+			ords.Default()
+
+			warnings, err := ords.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("resources.requests.cpu")))
+
+			ords.Spec.PodTemplate.Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}
+			warnings, err = ords.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should reject autoscaling a DaemonSet and a maximum below the minimum", func() {
+			ords.Spec.WorkloadType = "DaemonSet"
+			ords.Spec.Autoscaling = &AutoscalingSettings{MinReplicas: &[]int32{3}[0], MaxReplicas: 2}
+			ords.Default()
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(
+				HaveField("Field", "spec.autoscaling"),
+				HaveField("Field", "spec.autoscaling.maxReplicas"),
+			))
+		})
+	})
+	Context("When validating disruption budget settings", func() {
+		It("should reject both minAvailable and maxUnavailable", func() {
+			minAvailable, maxUnavailable := intstr.FromInt32(1), intstr.FromString("25%")
+			ords.Spec.DisruptionBudget = &DisruptionBudgetSettings{MinAvailable: &minAvailable, MaxUnavailable: &maxUnavailable}
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(HaveField("Field", "spec.disruptionBudget.maxUnavailable")))
+		})
+
+		It("should warn when node drains will be blocked", func() {
+			minAvailable := intstr.FromInt32(1)
+			ords.Spec.DisruptionBudget = &DisruptionBudgetSettings{MinAvailable: &minAvailable}
+			ords.Default()
+
+			warnings, err := ords.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("node drains will be blocked")))
+		})
+
+		It("should admit a disabled disruption budget for a DaemonSet", func() {
+			ords.Spec.WorkloadType = "DaemonSet"
+			ords.Spec.DisruptionBudget = &DisruptionBudgetSettings{Disabled: true}
+
+			_, err := ords.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+	Context("When validating rollout settings", func() {
+		It("should admit the settings of the workloadType", func() {
+			maxSurge := intstr.FromString("25%")
+			ords.Spec.Rollout = RolloutSettings{MaxSurge: &maxSurge, MinReadySeconds: 30, ProgressDeadlineSeconds: &[]int32{600}[0]}
+			ords.Default()
+
+			_, err := ords.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject the settings of other workload types", func() {
+			maxSurge := intstr.FromInt32(1)
+			ords.Spec.WorkloadType = "StatefulSet"
+			ords.Spec.Rollout = RolloutSettings{MaxSurge: &maxSurge, ProgressDeadlineSeconds: &[]int32{600}[0]}
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(
+				HaveField("Field", "spec.rollout.maxSurge"),
+				HaveField("Field", "spec.rollout.progressDeadlineSeconds"),
+			))
+
+			ords.Spec.WorkloadType = "Deployment"
+			ords.Spec.Rollout = RolloutSettings{Partition: &[]int32{1}[0]}
+			_, err = ords.ValidateCreate()
+			Expect(causes(err)).To(ConsistOf(HaveField("Field", "spec.rollout.partition")))
+		})
+
+		It("should reject a rollout that cannot make progress", func() {
+			zero := intstr.FromInt32(0)
+			ords.Spec.Rollout = RolloutSettings{MaxSurge: &zero, MaxUnavailable: &zero, MinReadySeconds: 60, ProgressDeadlineSeconds: &[]int32{60}[0]}
+			ords.Default()
+
+			_, err := ords.ValidateCreate()
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(causes(err)).To(ConsistOf(
+				HaveField("Field", "spec.rollout.maxUnavailable"),
+				HaveField("Field", "spec.rollout.progressDeadlineSeconds"),
+			))
+		})
+	})
+})
@@ -43,11 +43,42 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
-	timex "time"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSettings) DeepCopyInto(out *AutoscalingSettings) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSettings.
+func (in *AutoscalingSettings) DeepCopy() *AutoscalingSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateSecret) DeepCopyInto(out *CertificateSecret) {
 	*out = *in
@@ -63,6 +94,37 @@ func (in *CertificateSecret) DeepCopy() *CertificateSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRevision) DeepCopyInto(out *ConfigRevision) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRevision.
+func (in *ConfigRevision) DeepCopy() *ConfigRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRevisionSettings) DeepCopyInto(out *ConfigRevisionSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRevisionSettings.
+func (in *ConfigRevisionSettings) DeepCopy() *ConfigRevisionSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRevisionSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBWalletSecret) DeepCopyInto(out *DBWalletSecret) {
 	*out = *in
@@ -78,6 +140,46 @@ func (in *DBWalletSecret) DeepCopy() *DBWalletSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionBudgetSettings) DeepCopyInto(out *DisruptionBudgetSettings) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBudgetSettings.
+func (in *DisruptionBudgetSettings) DeepCopy() *DisruptionBudgetSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionBudgetSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Duration) DeepCopyInto(out *Duration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Duration.
+func (in *Duration) DeepCopy() *Duration {
+	if in == nil {
+		return nil
+	}
+	out := new(Duration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GlobalSettings) DeepCopyInto(out *GlobalSettings) {
 	*out = *in
@@ -88,17 +190,17 @@ func (in *GlobalSettings) DeepCopyInto(out *GlobalSettings) {
 	}
 	if in.CacheMetadataGraphQLExpireAfterAccess != nil {
 		in, out := &in.CacheMetadataGraphQLExpireAfterAccess, &out.CacheMetadataGraphQLExpireAfterAccess
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.CacheMetadataGraphQLExpireAfterWrite != nil {
 		in, out := &in.CacheMetadataGraphQLExpireAfterWrite, &out.CacheMetadataGraphQLExpireAfterWrite
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.CacheMetadataTimeout != nil {
 		in, out := &in.CacheMetadataTimeout, &out.CacheMetadataTimeout
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.CacheMetadataJWKSEnabled != nil {
@@ -118,12 +220,12 @@ func (in *GlobalSettings) DeepCopyInto(out *GlobalSettings) {
 	}
 	if in.CacheMetadataJWKSExpireAfterAccess != nil {
 		in, out := &in.CacheMetadataJWKSExpireAfterAccess, &out.CacheMetadataJWKSExpireAfterAccess
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.CacheMetadataJWKSExpireAfterWrite != nil {
 		in, out := &in.CacheMetadataJWKSExpireAfterWrite, &out.CacheMetadataJWKSExpireAfterWrite
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.DatabaseAPIEnabled != nil {
@@ -138,7 +240,7 @@ func (in *GlobalSettings) DeepCopyInto(out *GlobalSettings) {
 	}
 	if in.DBInvalidPoolTimeout != nil {
 		in, out := &in.DBInvalidPoolTimeout, &out.DBInvalidPoolTimeout
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.FeatureGraphQLMaxNestingDepth != nil {
@@ -153,7 +255,7 @@ func (in *GlobalSettings) DeepCopyInto(out *GlobalSettings) {
 	}
 	if in.SecurityCredentialsLockTime != nil {
 		in, out := &in.SecurityCredentialsLockTime, &out.SecurityCredentialsLockTime
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.StandaloneHTTPPort != nil {
@@ -168,7 +270,7 @@ func (in *GlobalSettings) DeepCopyInto(out *GlobalSettings) {
 	}
 	if in.StandaloneStopTimeout != nil {
 		in, out := &in.StandaloneStopTimeout, &out.StandaloneStopTimeout
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.DebugPrintDebugToScreen != nil {
@@ -193,12 +295,12 @@ func (in *GlobalSettings) DeepCopyInto(out *GlobalSettings) {
 	}
 	if in.MongoIdleTimeout != nil {
 		in, out := &in.MongoIdleTimeout, &out.MongoIdleTimeout
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.MongoOpTimeout != nil {
 		in, out := &in.MongoOpTimeout, &out.MongoOpTimeout
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.SecurityDisableDefaultExclusionList != nil {
@@ -216,6 +318,13 @@ func (in *GlobalSettings) DeepCopyInto(out *GlobalSettings) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AdditionalSettings != nil {
+		in, out := &in.AdditionalSettings, &out.AdditionalSettings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.CertSecret != nil {
 		in, out := &in.CertSecret, &out.CertSecret
 		*out = new(CertificateSecret)
@@ -233,6 +342,113 @@ func (in *GlobalSettings) DeepCopy() *GlobalSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRouteParentRef) DeepCopyInto(out *HTTPRouteParentRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRouteParentRef.
+func (in *HTTPRouteParentRef) DeepCopy() *HTTPRouteParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRouteParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRouteSettings) DeepCopyInto(out *HTTPRouteSettings) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]HTTPRouteParentRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRouteSettings.
+func (in *HTTPRouteSettings) DeepCopy() *HTTPRouteSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRouteSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ImagePullSecrets) DeepCopyInto(out *ImagePullSecrets) {
+	{
+		in := &in
+		*out = make(ImagePullSecrets, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecrets.
+func (in ImagePullSecrets) DeepCopy() ImagePullSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecrets)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSettings) DeepCopyInto(out *IngressSettings) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSettings.
+func (in *IngressSettings) DeepCopy() *IngressSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PasswordSecret) DeepCopyInto(out *PasswordSecret) {
 	*out = *in
@@ -248,6 +464,77 @@ func (in *PasswordSecret) DeepCopy() *PasswordSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolSettings) DeepCopyInto(out *PoolSettings) {
 	*out = *in
@@ -256,7 +543,7 @@ func (in *PoolSettings) DeepCopyInto(out *PoolSettings) {
 	out.DBCDBAdminUserSecret = in.DBCDBAdminUserSecret
 	if in.DBPoolDestroyTimeout != nil {
 		in, out := &in.DBPoolDestroyTimeout, &out.DBPoolDestroyTimeout
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.DebugTrackResources != nil {
@@ -291,27 +578,27 @@ func (in *PoolSettings) DeepCopyInto(out *PoolSettings) {
 	}
 	if in.SecurityJWKSConnectionTimeout != nil {
 		in, out := &in.SecurityJWKSConnectionTimeout, &out.SecurityJWKSConnectionTimeout
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.SecurityJWKSReadTimeout != nil {
 		in, out := &in.SecurityJWKSReadTimeout, &out.SecurityJWKSReadTimeout
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.SecurityJWKSRefreshInterval != nil {
 		in, out := &in.SecurityJWKSRefreshInterval, &out.SecurityJWKSRefreshInterval
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.SecurityJWTAllowedSkew != nil {
 		in, out := &in.SecurityJWTAllowedSkew, &out.SecurityJWTAllowedSkew
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.SecurityJWTAllowedAge != nil {
 		in, out := &in.SecurityJWTAllowedAge, &out.SecurityJWTAllowedAge
-		*out = new(timex.Duration)
+		*out = new(Duration)
 		**out = **in
 	}
 	if in.DBPort != nil {
@@ -379,6 +666,13 @@ func (in *PoolSettings) DeepCopyInto(out *PoolSettings) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AdditionalSettings != nil {
+		in, out := &in.AdditionalSettings, &out.AdditionalSettings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.DBWalletSecret != nil {
 		in, out := &in.DBWalletSecret, &out.DBWalletSecret
 		*out = new(DBWalletSecret)
@@ -401,6 +695,29 @@ func (in *PoolSettings) DeepCopy() *PoolSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolStatus) DeepCopyInto(out *PoolStatus) {
+	*out = *in
+	if in.LastActionTime != nil {
+		in, out := &in.LastActionTime, &out.LastActionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastConnectionCheck != nil {
+		in, out := &in.LastConnectionCheck, &out.LastConnectionCheck
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolStatus.
+func (in *PoolStatus) DeepCopy() *PoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestDataServices) DeepCopyInto(out *RestDataServices) {
 	*out = *in
@@ -463,6 +780,40 @@ func (in *RestDataServicesList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestDataServicesSpec) DeepCopyInto(out *RestDataServicesSpec) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ConfigRevisions = in.ConfigRevisions
+	in.Rollout.DeepCopyInto(&out.Rollout)
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(DisruptionBudgetSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make(ImagePullSecrets, len(*in))
+		copy(*out, *in)
+	}
+	if in.InitScript != nil {
+		in, out := &in.InitScript, &out.InitScript
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(HTTPRouteSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	in.GlobalSettings.DeepCopyInto(&out.GlobalSettings)
 	if in.PoolSettings != nil {
 		in, out := &in.PoolSettings, &out.PoolSettings
@@ -500,6 +851,20 @@ func (in *RestDataServicesStatus) DeepCopyInto(out *RestDataServicesStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ConfigRevisions != nil {
+		in, out := &in.ConfigRevisions, &out.ConfigRevisions
+		*out = make([]ConfigRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]PoolStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -519,6 +884,96 @@ func (in *RestDataServicesStatus) DeepCopy() *RestDataServicesStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutSettings) DeepCopyInto(out *RolloutSettings) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSettings.
+func (in *RolloutSettings) DeepCopy() *RolloutSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceNodePorts) DeepCopyInto(out *ServiceNodePorts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceNodePorts.
+func (in *ServiceNodePorts) DeepCopy() *ServiceNodePorts {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceNodePorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSettings) DeepCopyInto(out *ServiceSettings) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.NodePorts = in.NodePorts
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSettings.
+func (in *ServiceSettings) DeepCopy() *ServiceSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TNSAdminSecret) DeepCopyInto(out *TNSAdminSecret) {
 	*out = *in
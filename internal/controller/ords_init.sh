@@ -83,6 +83,7 @@ function run_sql {
 
 	if (( ${_rc} > 0 )); then
 		echo "SQLERROR: ${_output}"
+		result_error="${_output}"
 	fi
 	
 	return $_rc
@@ -130,7 +131,7 @@ function create_adb_user() {
 	local _config_user=$($ords_cfg_cmd get db.username | tail -1)
 
 	if [[ -z ${_config_user} ]] || [[ ${_config_user} == "ORDS_PUBLIC_USER" ]]; then
-		echo "FATAL: You must specify a db.username <> ORDS_PUBLIC_USER in pool ${_pool_name}"
+		fatal "You must specify a db.username <> ORDS_PUBLIC_USER in pool ${_pool_name}"
 		return 1
 	fi
 
@@ -316,104 +317,326 @@ apex_upgrade() {
 }
 
 #------------------------------------------------------------------------------
-# INIT
+# Results of each pool, written as JSON with the ORDS version to the termination message of the container
+declare -a results=()
+
+fatal() {
+	echo "FATAL: ${1}"
+	result_error="${1}"
+}
+
+reset_result() {
+	result_db_type=""
+	result_ords_version=""
+	result_apex_version=""
+	result_action="none"
+	result_connected=false
+	result_error=""
+}
+
+json_escape() {
+	local _value="${1//\\/\\\\}"
+	_value="${_value//\"/\\\"}"
+	_value="${_value//$'\n'/\\n}"
+	_value="${_value//$'\t'/ }"
+	_value="${_value//$'\r'/}"
+	printf '%s' "${_value}"
+}
+
+add_result() {
+	local -r _pool_name="${1}"
+	local -r _exit_code="${2}"
+	local _result="Succeeded"
+	if (( ${_exit_code} > 0 )); then
+		_result="Failed"
+	fi
+	results+=("{\"pool\":\"$(json_escape "${_pool_name}")\",\"exitCode\":${_exit_code},\"result\":\"${_result}\",\"action\":\"${result_action}\",\"dbType\":\"${result_db_type}\",\"ordsVersion\":\"$(json_escape "${result_ords_version}")\",\"apexVersion\":\"$(json_escape "${result_apex_version}")\",\"connected\":${result_connected},\"error\":\"$(json_escape "${result_error:0:200}")\"}")
+}
+
+write_results() {
+	local IFS=,
+	local _ords_version=$(ords --version 2>/dev/null | grep -oE '[0-9]+(\.[0-9]+){2}[.0-9a-z]*' | head -1)
+	echo "{\"ordsVersion\":\"${_ords_version}\",\"pools\":[${results[*]}]}" > "${TERMINATION_LOG:-/dev/termination-log}"
+}
+
 #------------------------------------------------------------------------------
-declare -A pool_exit
-for pool in "$ORDS_CONFIG"/databases/*; do
-	rc=0
-	pool_name=$(basename "$pool")
-	pool_exit[${pool_name}]=0
-	ords_cfg_cmd="ords --config $ORDS_CONFIG config --db-pool ${pool_name}"
-	echo "Found Pool: $pool_name..."
+function get_versions() {
+	local -r _conn_string="${1}"
 
-	declare -A config
+	local -r _ver_sql="
+		DECLARE
+			l_ords VARCHAR2(255);
+			l_apex VARCHAR2(255);
+		BEGIN
+			BEGIN
+				EXECUTE IMMEDIATE 'SELECT version FROM ords_metadata.ords_version' INTO l_ords;
+			EXCEPTION WHEN OTHERS THEN
+				l_ords := NULL;
+			END;
+			BEGIN
+				EXECUTE IMMEDIATE q'[SELECT version FROM dba_registry WHERE comp_id='APEX']' INTO l_apex;
+			EXCEPTION WHEN OTHERS THEN
+				l_apex := NULL;
+			END;
+			DBMS_OUTPUT.PUT_LINE(l_ords||','||l_apex);
+		END;
+		/"
+	run_sql "${_conn_string}" "${_ver_sql}" "_versions" || return 1
+
+	_versions=${_versions//[^0-9.,]/}
+	result_ords_version=${_versions%%,*}
+	result_apex_version=${_versions#*,}
+	echo "Database ORDS Version: ${result_ords_version:-Not Installed}, APEX Version: ${result_apex_version:-Not Installed}"
+}
+
+#------------------------------------------------------------------------------
+get_config() {
+	local -r _pool_name="${1}"
+
+	declare -gA config
 	for key in dbsecret dbadminusersecret dbcdbadminusersecret; do
-		var_key="${pool_name//-/_}_${key}"
+		var_key="${_pool_name//-/_}_${key}"
 		echo "Obtaining value from initContainer variable: ${var_key}"
 		var_val="${!var_key}"
 		config[${key}]="${var_val}"
 	done
+}
+
+#------------------------------------------------------------------------------
+pool_secrets() {
+	local -r _pool_name="${1}"
+	local -i _rc=0
+
+	get_config "${_pool_name}"
 
 	# Set Secrets
-	set_secret "${pool_name}" "db.password" "${config["dbsecret"]}"
-	rc=$((rc + $?))
-	set_secret "${pool_name}" "db.adminUser.password" "${config["dbadminusersecret"]}"
-	rc=$((rc + $?))
-	set_secret "${pool_name}" "db.cdb.adminUser.password" "${config["dbcdbadminusersecret"]}"
-	rc=$((rc + $?))
-
-	if (( ${rc} > 0 )); then
-		echo "FATAL: Unable to set configuration for pool ${pool_name}"
-		pool_exit[${pool_name}]=1
-		continue
+	set_secret "${_pool_name}" "db.password" "${config["dbsecret"]}"
+	_rc=$((_rc + $?))
+	set_secret "${_pool_name}" "db.adminUser.password" "${config["dbadminusersecret"]}"
+	_rc=$((_rc + $?))
+	set_secret "${_pool_name}" "db.cdb.adminUser.password" "${config["dbcdbadminusersecret"]}"
+	_rc=$((_rc + $?))
+
+	if (( ${_rc} > 0 )); then
+		fatal "Unable to set configuration for pool ${_pool_name}"
+		return 1
 	elif [[ -z ${config["dbsecret"]} ]]; then
-		echo "FATAL: db.password must be specified for ${pool_name}"
-		pool_exit[${pool_name}]=1
-		continue
-	elif [[ -z ${config["dbadminusersecret"]} ]]; then
-		echo "INFO: No additional configuration for ${pool_name}"
-		continue
+		fatal "db.password must be specified for ${_pool_name}"
+		return 1
+	fi
+
+	return 0
+}
+
+#------------------------------------------------------------------------------
+pool_install() {
+	local -r _pool_name="${1}"
+	local -i _rc=0
+	local -a _actions=()
+
+	ords_cfg_cmd="ords --config $ORDS_CONFIG config --db-pool ${_pool_name}"
+	echo "Installing Pool: ${_pool_name}..."
+
+	pool_secrets "${_pool_name}" || return 1
+	if [[ -z ${config["dbadminusersecret"]} ]]; then
+		echo "INFO: No additional configuration for ${_pool_name}"
+		return 0
 	fi
 
 	get_conn_string "conn_string"
 	if [[ -z ${conn_string} ]]; then
-		echo "FATAL: Unable to get ${pool_name} database connect string"
-		pool_exit[${pool_name}]=1
-		continue
+		fatal "Unable to get ${_pool_name} database connect string"
+		return 1
 	fi
 
 	check_adb "${conn_string}" "is_adb"
-	rc=$?
-	if (( ${rc} > 0 )); then
-		pool_exit[${pool_name}]=1
-		continue
+	_rc=$?
+	if (( ${_rc} > 0 )); then
+		return ${_rc}
 	fi
+	result_connected=true
+	result_error=""
 
 	if (( is_adb )); then
 		# Create ORDS User
-		echo "Processing ADB in Pool: ${pool_name}"
-		create_adb_user "${conn_string}" "${pool_name}"
-	else	
-		# APEX Upgrade
-		echo "---------------------------------------------------"
-		apex_upgrade_var=${pool_name}_autoupgrade_apex
-		if [[ ${!apex_upgrade_var} != "true" ]]; then
-			echo "APEX Install/Upgrade not requested for ${pool_name}"
-			continue
-		fi
+		result_db_type="ADB"
+		echo "Processing ADB in Pool: ${_pool_name}"
+		result_action="createUser"
+		create_adb_user "${conn_string}" "${_pool_name}"
+		_rc=$?
+		get_versions "${conn_string}"
+		return ${_rc}
+	fi
+	result_db_type="Oracle"
 
+	# APEX Upgrade
+	echo "---------------------------------------------------"
+	local -r apex_upgrade_var=${_pool_name//-/_}_autoupgrade_apex
+	if [[ ${!apex_upgrade_var} != "true" ]]; then
+		echo "APEX Install/Upgrade not requested for ${_pool_name}"
+	else
 		get_apex_version "${conn_string}" "action"
 		if [[ -z ${action} ]]; then
-			echo "FATAL: Unable to get ${pool_name} APEX Version"
-			pool_exit[${pool_name}]=1
-			continue
+			fatal "Unable to get ${_pool_name} APEX Version"
+			return 1
 		fi
 
 		if [[ ${action} != "none" ]]; then
-			apex_upgrade "${conn_string}" "${pool_name}_autoupgrade_apex"
+			_actions+=("apex${action^}")
+			result_action=$(IFS=,; echo "${_actions[*]}")
+			apex_upgrade "${conn_string}" "${apex_upgrade_var}"
 			if (( $? > 0 )); then
-				echo "FATAL: Unable to ${action} APEX for ${pool_name}"
-				pool_exit[${pool_name}]=1
-				continue
-			fi			
+				fatal "Unable to ${action} APEX for ${_pool_name}"
+				return 1
+			fi
 		fi
+	fi
 
-		# ORDS Upgrade
-		ords_upgrade_var=${pool_name}_autoupgrade_ords
-		if [[ ${!ords_upgrade_var} != "true" ]]; then
-			echo "ORDS Install/Upgrade not requested for ${pool_name}"
-			continue
+	# ORDS Upgrade
+	local -r ords_upgrade_var=${_pool_name//-/_}_autoupgrade_ords
+	if [[ ${!ords_upgrade_var} != "true" ]]; then
+		echo "ORDS Install/Upgrade not requested for ${_pool_name}"
+	else
+		_actions+=("ordsInstall")
+		result_action=$(IFS=,; echo "${_actions[*]}")
+		ords_upgrade "${_pool_name}" "${ords_upgrade_var}"
+		_rc=$?
+		if (( $_rc > 0 )); then
+			fatal "Unable to preform requested ORDS install/upgrade on ${_pool_name}"
+			return 1
 		fi
+	fi
 
-		ords_upgrade "${pool_name}" "${pool_name}_autoupgrade_ords"
-		rc=$?
-		if (( $rc > 0 )); then
-			echo "FATAL: Unable to preform requested ORDS install/upgrade on ${pool_name}"
-			pool_exit[${pool_name}]=1
-			continue
+	get_versions "${conn_string}"
+	return 0
+}
+
+#------------------------------------------------------------------------------
+function drop_adb_user() {
+	local -r _conn_string="${1}"
+	local -r _pool_name="${2}"
+
+	local _config_user=$($ords_cfg_cmd get db.username | tail -1)
+
+	if [[ -z ${_config_user} ]] || [[ ${_config_user} == "ORDS_PUBLIC_USER" ]]; then
+		fatal "You must specify a db.username <> ORDS_PUBLIC_USER in pool ${_pool_name}"
+		return 1
+	fi
+
+	local -r _adb_user_sql="
+    DECLARE
+      l_user VARCHAR2(255);
+    BEGIN
+      SELECT USERNAME INTO l_user FROM DBA_USERS WHERE USERNAME='${_config_user}';
+      EXECUTE IMMEDIATE 'DROP USER \"${_config_user}\" CASCADE';
+      DBMS_OUTPUT.PUT_LINE('${_config_user} Dropped');
+    EXCEPTION
+      WHEN NO_DATA_FOUND THEN
+        DBMS_OUTPUT.PUT_LINE('${_config_user} does not exist');
+    END;
+	/"
+
+	run_sql "${_conn_string}" "${_adb_user_sql}" "_adb_user_sql_output"
+	_rc=$?
+
+	echo "Uninstallation Output: ${_adb_user_sql_output}"
+	return ${_rc}
+}
+
+#------------------------------------------------------------------------------
+ords_uninstall() {
+	local -r _pool_name="${1}"
+	local -i _rc=0
+
+	ords_cfg_cmd="ords --config $ORDS_CONFIG config --db-pool ${_pool_name}"
+	echo "Uninstalling Pool: ${_pool_name}..."
+
+	pool_secrets "${_pool_name}" || return 1
+	if [[ -z ${config["dbadminusersecret"]} ]]; then
+		fatal "db.adminUser.password must be specified for ${_pool_name}"
+		return 1
+	fi
+
+	get_conn_string "conn_string"
+	if [[ -z ${conn_string} ]]; then
+		fatal "Unable to get ${_pool_name} database connect string"
+		return 1
+	fi
+
+	check_adb "${conn_string}" "is_adb"
+	_rc=$?
+	if (( ${_rc} > 0 )); then
+		return ${_rc}
+	fi
+	result_connected=true
+	result_error=""
+
+	if (( is_adb )); then
+		# Drop the ORDS User created by create_adb_user
+		result_db_type="ADB"
+		echo "Processing ADB in Pool: ${_pool_name}"
+		drop_adb_user "${conn_string}" "${_pool_name}"
+		_rc=$?
+	else
+		result_db_type="Oracle"
+		local -r ords_admin=$($ords_cfg_cmd get db.adminUser | tail -1)
+		echo "Performing ORDS uninstall as $ords_admin on pool ${_pool_name}"
+		ords --config "$ORDS_CONFIG" uninstall --db-pool "${_pool_name}" --force \
+			--admin-user "$ords_admin" --password-stdin <<< "${config["dbadminusersecret"]}"
+		_rc=$?
+		if (( ${_rc} > 0 )); then
+			fatal "Unable to perform the ORDS uninstall on ${_pool_name}"
 		fi
 	fi
+
+	return ${_rc}
+}
+
+#------------------------------------------------------------------------------
+# INSTALL/UNINSTALL (Job)
+#------------------------------------------------------------------------------
+case "${1}" in
+	install)
+		reset_result
+		pool_install "${2}"
+		rc=$?
+		add_result "${2}" ${rc}
+		write_results
+		exit ${rc}
+		;;
+	uninstall)
+		reset_result
+		result_action="uninstall"
+		ords_uninstall "${2}"
+		rc=$?
+		add_result "${2}" ${rc}
+		write_results
+		exit ${rc}
+		;;
+esac
+
+#------------------------------------------------------------------------------
+# INIT
+#------------------------------------------------------------------------------
+# Installation and upgrades are performed by the install Job of each pool;
+# the initContainer only sets the pool secrets in the wallets
+declare -A pool_exit
+rc=0
+for pool in "$ORDS_CONFIG"/databases/*; do
+	pool_name=$(basename "$pool")
+	pool_exit[${pool_name}]=0
+	ords_cfg_cmd="ords --config $ORDS_CONFIG config --db-pool ${pool_name}"
+	echo "Found Pool: $pool_name..."
+
+	reset_result
+	result_action="setSecrets"
+	pool_secrets "${pool_name}"
+	if (( $? > 0 )); then
+		pool_exit[${pool_name}]=1
+	fi
+	add_result "${pool_name}" ${pool_exit[${pool_name}]}
 done
+write_results
 
 for key in "${!pool_exit[@]}"; do
     echo "Pool: $key, Exit Code: ${pool_exit[$key]}"
@@ -422,4 +645,4 @@ for key in "${!pool_exit[@]}"; do
 	fi
 done
 
-exit $rc
\ No newline at end of file
+exit $rc
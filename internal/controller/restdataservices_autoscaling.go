@@ -0,0 +1,109 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+/************************************************
+ * HorizontalPodAutoscaler
+ *************************************************/
+// AutoscalerReconcile creates or updates the HorizontalPodAutoscaler of spec.autoscaling, deleting it once no longer specified
+func (r *RestDataServicesReconciler) AutoscalerReconcile(ctx context.Context, ords *databasev1.RestDataServices) error {
+	definedAutoscaler := &autoscalingv2.HorizontalPodAutoscaler{}
+	var desiredAutoscaler client.Object
+	var desiredSpecHash string
+	if autoscaled(ords) {
+		desiredAutoscaler, desiredSpecHash = autoscalerDefine(ords)
+	}
+	return r.ownedReconcile(ctx, ords, "HorizontalPodAutoscaler", definedAutoscaler, desiredAutoscaler, desiredSpecHash)
+}
+
+func autoscalerDefine(ords *databasev1.RestDataServices) (*autoscalingv2.HorizontalPodAutoscaler, string) {
+	autoscaling := ords.Spec.Autoscaling
+	var metrics []autoscalingv2.MetricSpec
+	for _, target := range []struct {
+		name                     corev1.ResourceName
+		averageUtilizationTarget *int32
+	}{
+		{corev1.ResourceCPU, autoscaling.TargetCPUUtilizationPercentage},
+		{corev1.ResourceMemory, autoscaling.TargetMemoryUtilizationPercentage},
+	} {
+		if target.averageUtilizationTarget == nil {
+			continue
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: target.name,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: target.averageUtilizationTarget,
+				},
+			},
+		})
+	}
+	spec := autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       ords.Spec.WorkloadType,
+			Name:       ords.Name,
+		},
+		MinReplicas: autoscaling.MinReplicas,
+		MaxReplicas: autoscaling.MaxReplicas,
+		Metrics:     metrics,
+	}
+
+	objectMeta := objectMetaDefine(ords, ords.Name)
+	specHash := generateSpecHash(spec)
+	objectMeta.Labels[specHashLabel] = specHash
+	return &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: objectMeta, Spec: spec}, specHash
+}
+
+// autoscaled returns true when the Workload is scaled by a HorizontalPodAutoscaler; DaemonSets cannot be scaled
+func autoscaled(ords *databasev1.RestDataServices) bool {
+	return ords.Spec.Autoscaling != nil && ords.Spec.WorkloadType != "DaemonSet"
+}
@@ -0,0 +1,128 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Autoscaling", func() {
+	ctx := context.Background()
+	var reconciler *RestDataServicesReconciler
+	var ords *databasev1.RestDataServices
+
+	autoscalerKey := func() types.NamespacedName {
+		return types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+			Spec:       databasev1.RestDataServicesSpec{Image: "container-registry.oracle.com/database/ords:24.1.0"},
+		}
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+	})
+
+	It("should not create a HorizontalPodAutoscaler when not specified", func() {
+		Expect(reconciler.AutoscalerReconcile(ctx, ords)).To(Succeed())
+		err := reconciler.Get(ctx, autoscalerKey(), &autoscalingv2.HorizontalPodAutoscaler{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should scale the Workload on the utilization targets", func() {
+		ords.Spec.WorkloadType = "StatefulSet"
+		ords.Spec.Replicas = 2
+		ords.Spec.Autoscaling = &databasev1.AutoscalingSettings{
+			MaxReplicas:                       6,
+			TargetMemoryUtilizationPercentage: &[]int32{75}[0],
+		}
+		ords.Spec.SetDefaults()
+		Expect(reconciler.AutoscalerReconcile(ctx, ords)).To(Succeed())
+
+		autoscaler := &autoscalingv2.HorizontalPodAutoscaler{}
+		Expect(reconciler.Get(ctx, autoscalerKey(), autoscaler)).To(Succeed())
+		Expect(metav1.IsControlledBy(autoscaler, ords)).To(BeTrue())
+		Expect(autoscaler.Spec.ScaleTargetRef).To(Equal(autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1", Kind: "StatefulSet", Name: ords.Name,
+		}))
+		Expect(*autoscaler.Spec.MinReplicas).To(Equal(int32(2)))
+		Expect(autoscaler.Spec.MaxReplicas).To(Equal(int32(6)))
+		Expect(autoscaler.Spec.Metrics).To(HaveLen(1))
+		Expect(autoscaler.Spec.Metrics[0].Resource.Name).To(Equal(corev1.ResourceMemory))
+		Expect(*autoscaler.Spec.Metrics[0].Resource.Target.AverageUtilization).To(Equal(int32(75)))
+
+		ords.Spec.Autoscaling.MaxReplicas = 8
+		Expect(reconciler.AutoscalerReconcile(ctx, ords)).To(Succeed())
+		Expect(reconciler.Get(ctx, autoscalerKey(), autoscaler)).To(Succeed())
+		Expect(autoscaler.Spec.MaxReplicas).To(Equal(int32(8)))
+	})
+
+	It("should delete the HorizontalPodAutoscaler once no longer specified", func() {
+		ords.Spec.Autoscaling = &databasev1.AutoscalingSettings{MaxReplicas: 3}
+		ords.Spec.SetDefaults()
+		Expect(reconciler.AutoscalerReconcile(ctx, ords)).To(Succeed())
+		Expect(reconciler.Get(ctx, autoscalerKey(), &autoscalingv2.HorizontalPodAutoscaler{})).To(Succeed())
+
+		ords.Spec.Autoscaling = nil
+		Expect(reconciler.AutoscalerReconcile(ctx, ords)).To(Succeed())
+		err := reconciler.Get(ctx, autoscalerKey(), &autoscalingv2.HorizontalPodAutoscaler{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should report the replicas and selector for the scale subresource", func() {
+		_, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		condition := metav1.Condition{Type: typeAvailableORDS, Status: metav1.ConditionTrue, Reason: "Available", Message: "Workload in Sync"}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}}
+		Expect(reconciler.SetStatus(ctx, req, ords, condition)).To(Succeed())
+		Expect(ords.Status.Selector).To(Equal("app.kubernetes.io/instance=ords,oracle.com/ords-operator-filter=oracle-ords-operator"))
+	})
+})
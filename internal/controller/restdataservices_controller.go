@@ -45,42 +45,60 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	databasev1 "example.com/oracle-ords-operator/api/v1"
 )
 
 // Definitions of Standards
 const (
-	ordsSABase           = "/opt/oracle/sa"
-	serviceHTTPPortName  = "svc-http-port"
-	serviceHTTPSPortName = "svc-https-port"
-	serviceMongoPortName = "svc-mongo-port"
-	targetHTTPPortName   = "pod-http-port"
-	targetHTTPSPortName  = "pod-https-port"
-	targetMongoPortName  = "pod-mongo-port"
-	globalConfigMapName  = "settings-global"
-	poolConfigPreName    = "settings-" // Append PoolName
-	controllerLabelKey   = "oracle.com/ords-operator-filter"
-	controllerLabelVal   = "oracle-ords-operator"
-	specHashLabel        = "oracle.com/ords-operator-spec-hash"
+	ordsSABase                = "/opt/oracle/sa"
+	serviceHTTPPortName       = "svc-http-port"
+	serviceHTTPSPortName      = "svc-https-port"
+	serviceMongoPortName      = "svc-mongo-port"
+	targetHTTPPortName        = "pod-http-port"
+	targetHTTPSPortName       = "pod-https-port"
+	targetMongoPortName       = "pod-mongo-port"
+	globalConfigMapName       = "settings-global"
+	poolConfigPreName         = "settings-" // Append PoolName
+	controllerLabelKey        = "oracle.com/ords-operator-filter"
+	controllerLabelVal        = "oracle-ords-operator"
+	specHashLabel             = "oracle.com/ords-operator-spec-hash"
+	configHashAnnotation      = "oracle.com/ords-operator-config-hash"
+	uninstallAnnotation       = "oracle.com/ords-operator-uninstall"
+	ordsFinalizer             = "oracle.com/ords-operator-finalizer"
+	approveUpgradeAnnotation  = "oracle.com/ords-operator-approve-upgrade"
+	serviceMetadataAnnotation = "oracle.com/ords-operator-service-metadata"
+	secretIndexKey            = ".spec.secretNames"
+	initScriptIndexKey        = ".spec.initScript.name"
 )
 
 // Definitions to manage status conditions
@@ -89,16 +107,27 @@ const (
 	typeAvailableORDS = "Available"
 	// typeUnsyncedORDS represents the status used when the configuration has changed but the Workload has not been restarted.
 	typeUnsyncedORDS = "Unsynced"
+	// typeInstalledORDS represents the status of installing/upgrading ORDS in the databases of the pools
+	typeInstalledORDS = "Installed"
+	// typePendingUpgradeORDS represents the status of pool upgrades awaiting approval
+	typePendingUpgradeORDS = "PendingUpgrade"
+	// typeUninstallingORDS represents the status of uninstalling ORDS from the database of a removed pool or deleted resource
+	typeUninstallingORDS = "Uninstalling"
+	// typeInitializedORDS represents the status of the Workload pods init container setting up the pools
+	typeInitializedORDS = "Initialized"
+	// typeProgressingORDS represents the status of the Workload rolling out its pods
+	typeProgressingORDS = "Progressing"
+	// typeMigratingORDS represents the status of replacing the Workload of the previous workloadType
+	typeMigratingORDS = "Migrating"
 )
 
-// Trigger a restart of Pods on Config Changes
-var RestartPods bool = false
-
 // RestDataServicesReconciler reconciles a RestDataServices object
 type RestDataServicesReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// MaxConcurrentReconciles is the maximum number of RestDataServices reconciled at once
+	MaxConcurrentReconciles int
 }
 
 //+kubebuilder:rbac:groups=database.oracle.com,resources=restdataservices,verbs=get;list;watch;create;update;patch;delete
@@ -109,6 +138,7 @@ type RestDataServicesReconciler struct {
 //+kubebuilder:rbac:groups=core,resources=configmaps/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=secrets/status,verbs=get
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
@@ -117,17 +147,49 @@ type RestDataServicesReconciler struct {
 //+kubebuilder:rbac:groups=core,resources=daemonsets/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=statefulsets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RestDataServicesReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	// Index the referenced Secrets to reconcile the resources referencing a changed Secret
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &databasev1.RestDataServices{}, secretIndexKey,
+		secretIndexValues); err != nil {
+		return err
+	}
+	// Index the init script ConfigMap to reconcile the resources using a changed script
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &databasev1.RestDataServices{}, initScriptIndexKey,
+		initScriptIndexValues); err != nil {
+		return err
+	}
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&databasev1.RestDataServices{}).
 		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Secret{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.secretToRequests)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.initScriptToRequests)).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(podToRequests),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetLabels()[controllerLabelKey] == controllerLabelVal
+			}))).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&corev1.Service{}).
+		Owns(&batchv1.Job{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&networkingv1.Ingress{})
+	// HTTPRoutes are only watched when the Gateway API CRDs are installed
+	if _, err := mgr.GetRESTMapper().RESTMapping(httpRouteGVK.GroupKind(), httpRouteGVK.Version); err == nil {
+		httpRoute := &unstructured.Unstructured{}
+		httpRoute.SetGroupVersionKind(httpRouteGVK)
+		controllerBuilder = controllerBuilder.Owns(httpRoute)
+	}
+	return controllerBuilder.
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
 
@@ -136,7 +198,7 @@ func (r *RestDataServicesReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	ords := &databasev1.RestDataServices{}
 
 	// Check if resource exists or was deleted
-	if err := r.Get(ctx, req.NamespacedName, ords); err != nil {
+	if err := r.getDefaulted(ctx, req.NamespacedName, ords); err != nil {
 		if apierrors.IsNotFound(err) {
 			logr.Info("Resource deleted")
 			return ctrl.Result{}, nil
@@ -145,6 +207,17 @@ func (r *RestDataServicesReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
 	}
 
+	// Uninstall pools from the database before the resource is deleted
+	if !ords.DeletionTimestamp.IsZero() {
+		return r.FinalizeReconcile(ctx, req, ords)
+	}
+	if uninstallRequired(ords) {
+		if err := r.FinalizerReconcile(ctx, ords, true); err != nil {
+			logr.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Set the status as Unknown when no status are available
 	if ords.Status.Conditions == nil || len(ords.Status.Conditions) == 0 {
 		condition := metav1.Condition{Type: typeUnsyncedORDS, Status: metav1.ConditionUnknown, Reason: "Reconciling", Message: "Starting reconciliation"}
@@ -153,14 +226,32 @@ func (r *RestDataServicesReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
+	// Rendered ConfigMap data and referenced Secret hashes by name, hashed to detect configuration changes
+	renderedConfig := make(map[string]map[string]string)
+
+	// Configuration of the revision rolled back to, if any
+	rollbackConfig, err := r.ConfigRollbackLoad(ctx, ords)
+	if err != nil {
+		logr.Error(err, "Error in ConfigRollbackLoad")
+		condition := metav1.Condition{Type: typeAvailableORDS, Status: metav1.ConditionFalse, Reason: "RollbackFailed", Message: err.Error()}
+		if statusErr := r.SetStatus(ctx, req, ords, condition); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, err
+	}
+
 	// ConfigMap - Init Script
-	if err := r.ConfigMapReconcile(ctx, ords, ords.Name+"-"+"init-script", 0); err != nil {
+	if err := r.ConfigMapReconcile(ctx, ords, ords.Name+"-"+"init-script", 0, nil, renderedConfig); err != nil {
 		logr.Error(err, "Error in ConfigMapReconcile (init-script)")
+		condition := metav1.Condition{Type: typeAvailableORDS, Status: metav1.ConditionFalse, Reason: "InitScriptError", Message: err.Error()}
+		if statusErr := r.SetStatus(ctx, req, ords, condition); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
 		return ctrl.Result{}, err
 	}
 
 	// ConfigMap - Global Settings
-	if err := r.ConfigMapReconcile(ctx, ords, ords.Name+"-"+globalConfigMapName, 0); err != nil {
+	if err := r.ConfigMapReconcile(ctx, ords, ords.Name+"-"+globalConfigMapName, 0, rollbackConfig, renderedConfig); err != nil {
 		logr.Error(err, "Error in ConfigMapReconcile (Global)")
 		return ctrl.Result{}, err
 	}
@@ -174,7 +265,7 @@ func (r *RestDataServicesReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return ctrl.Result{}, errors.New("poolName: " + poolName + " is not unique")
 		}
 		definedPools[poolConfigMapName] = true
-		if err := r.ConfigMapReconcile(ctx, ords, poolConfigMapName, i); err != nil {
+		if err := r.ConfigMapReconcile(ctx, ords, poolConfigMapName, i, rollbackConfig, renderedConfig); err != nil {
 			logr.Error(err, "Error in ConfigMapReconcile (Pools)")
 			return ctrl.Result{}, err
 		}
@@ -183,38 +274,70 @@ func (r *RestDataServicesReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		logr.Error(err, "Error in ConfigMapDelete (Pools)")
 		return ctrl.Result{}, err
 	}
-	if err := r.Get(ctx, req.NamespacedName, ords); err != nil {
+
+	// ConfigMaps - Revisions of the Global and Pool Settings
+	settingsConfigMaps := []string{ords.Name + "-" + globalConfigMapName}
+	for poolConfigMapName := range definedPools {
+		settingsConfigMaps = append(settingsConfigMaps, poolConfigMapName)
+	}
+	if err := r.ConfigRevisionReconcile(ctx, req, ords, settingsConfigMaps, renderedConfig); err != nil {
+		logr.Error(err, "Error in ConfigRevisionReconcile")
+		return ctrl.Result{}, err
+	}
+	if err := r.getDefaulted(ctx, req.NamespacedName, ords); err != nil {
 		logr.Error(err, "Failed to re-fetch")
 		return ctrl.Result{}, err
 	}
 
-	// // Secrets - Pool Settings
-	// for i := 0; i < len(ords.Spec.PoolSettings); i++ {
-	// 	if err := r.SecretsReconcile(ctx, ords, i); err != nil {
-	// 		logr.Error(err, "Error in SecretsReconcile (Pools)")
-	// 		return ctrl.Result{}, err
-	// 	}
-	// }
+	// Secrets
+	if err := r.SecretsReconcile(ctx, ords, renderedConfig); err != nil {
+		logr.Error(err, "Error in SecretsReconcile")
+		return ctrl.Result{}, err
+	}
 
-	// Set the Type as Unsynced when a pod restart is required
-	if RestartPods {
-		condition := metav1.Condition{Type: typeUnsyncedORDS, Status: metav1.ConditionTrue, Reason: "Unsynced", Message: "Configurations have changed"}
-		if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+	// Install/Upgrade; the image of the Workload is held until the pools are installed with it
+	installed, err := r.InstallReconcile(ctx, req, ords)
+	if err != nil {
+		logr.Error(err, "Error in InstallReconcile")
+		return ctrl.Result{}, err
+	}
+	workloadOrds := ords
+	if !installed {
+		if workloadOrds, err = r.heldImage(ctx, ords); err != nil {
+			logr.Error(err, "Error in heldImage")
 			return ctrl.Result{}, err
 		}
 	}
 
-	// Workloads
-	if err := r.WorkloadReconcile(ctx, req, ords, ords.Spec.WorkloadType); err != nil {
-		logr.Error(err, "Error in WorkloadReconcile")
+	// Workloads; reconciled again when the install Jobs change
+	var restartRequired, migrating bool
+	if workloadOrds != nil {
+		configHash := generateSpecHash(renderedConfig)
+		restartRequired, err = r.WorkloadReconcile(ctx, req, workloadOrds, workloadOrds.Spec.WorkloadType, configHash)
+		if err != nil {
+			logr.Error(err, "Error in WorkloadReconcile")
+			return ctrl.Result{}, err
+		}
+		migrating, err = r.WorkloadDelete(ctx, req, workloadOrds, workloadOrds.Spec.WorkloadType)
+		if err != nil {
+			logr.Error(err, "Error in WorkloadDelete")
+			return ctrl.Result{}, err
+		}
+	}
+	if err := r.getDefaulted(ctx, req.NamespacedName, ords); err != nil {
+		logr.Error(err, "Failed to re-fetch")
 		return ctrl.Result{}, err
 	}
-	if err := r.WorkloadDelete(ctx, req, ords, ords.Spec.WorkloadType); err != nil {
-		logr.Error(err, "Error in WorkloadDelete")
+
+	// HorizontalPodAutoscaler
+	if err := r.AutoscalerReconcile(ctx, ords); err != nil {
+		logr.Error(err, "Error in AutoscalerReconcile")
 		return ctrl.Result{}, err
 	}
-	if err := r.Get(ctx, req.NamespacedName, ords); err != nil {
-		logr.Error(err, "Failed to re-fetch")
+
+	// PodDisruptionBudget
+	if err := r.DisruptionBudgetReconcile(ctx, ords, migrating); err != nil {
+		logr.Error(err, "Error in DisruptionBudgetReconcile")
 		return ctrl.Result{}, err
 	}
 
@@ -224,14 +347,64 @@ func (r *RestDataServicesReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
-	// Set the Type as Available when a pod restart is not required
-	if !RestartPods {
-		condition := metav1.Condition{Type: typeAvailableORDS, Status: metav1.ConditionTrue, Reason: "Available", Message: "Workload in Sync"}
+	// Ingress and HTTPRoute
+	if err := r.IngressReconcile(ctx, ords); err != nil {
+		logr.Error(err, "Error in IngressReconcile")
+		return ctrl.Result{}, err
+	}
+	if err := r.HTTPRouteReconcile(ctx, ords); err != nil {
+		logr.Error(err, "Error in HTTPRouteReconcile")
+		return ctrl.Result{}, err
+	}
+
+	// Init container results of the Workload pods
+	initialized, err := r.InitReconcile(ctx, req, ords)
+	if err != nil {
+		logr.Error(err, "Error in InitReconcile")
+		return ctrl.Result{}, err
+	}
+
+	// Uninstall removed pools; the finalizer is kept until they are uninstalled
+	uninstalled, err := r.UninstallReconcile(ctx, req, ords, false)
+	if err != nil {
+		logr.Error(err, "Error in UninstallReconcile")
+		return ctrl.Result{}, err
+	}
+	if err := r.FinalizerReconcile(ctx, ords, !uninstalled || uninstallRequired(ords)); err != nil {
+		logr.Error(err, "Failed to update finalizer")
+		return ctrl.Result{}, err
+	}
+
+	// Set the Type as Unsynced when a pod restart is required, otherwise as Available; the
+	// Installed condition reports why the image of the Workload is held
+	if restartRequired {
+		condition := metav1.Condition{Type: typeUnsyncedORDS, Status: metav1.ConditionTrue, Reason: "Unsynced", Message: "Configurations have changed"}
+		if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if !installed {
+		logr.Info("Workload image held until the pools are installed")
+	} else if !initialized {
+		condition := metav1.Condition{Type: typeAvailableORDS, Status: metav1.ConditionFalse, Reason: "InitFailed", Message: "Workload pods failed to initialize pools"}
+		if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if migrating {
+		condition := metav1.Condition{Type: typeAvailableORDS, Status: metav1.ConditionTrue, Reason: "Migrating", Message: "Previous Workload serving until the pods of the new Workload are ready"}
+		if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else {
+		condition := metav1.Condition{Type: typeUnsyncedORDS, Status: metav1.ConditionFalse, Reason: "Synced", Message: "Workload in Sync"}
+		if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+			return ctrl.Result{}, err
+		}
+		condition = metav1.Condition{Type: typeAvailableORDS, Status: metav1.ConditionTrue, Reason: "Available", Message: "Workload in Sync"}
 		if err := r.SetStatus(ctx, req, ords, condition); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
-	if err := r.Get(ctx, req.NamespacedName, ords); err != nil {
+	if err := r.getDefaulted(ctx, req.NamespacedName, ords); err != nil {
 		logr.Error(err, "Failed to re-fetch")
 		return ctrl.Result{}, err
 	}
@@ -239,6 +412,16 @@ func (r *RestDataServicesReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	return ctrl.Result{}, nil
 }
 
+// getDefaulted fetches the resource and applies the spec defaults so that the
+// controller works from the same spec as the defaulting webhook would produce
+func (r *RestDataServicesReconciler) getDefaulted(ctx context.Context, key types.NamespacedName, ords *databasev1.RestDataServices) error {
+	if err := r.Get(ctx, key, ords); err != nil {
+		return err
+	}
+	ords.Spec.SetDefaults()
+	return nil
+}
+
 /************************************************
  * Status
  *************************************************/
@@ -246,36 +429,51 @@ func (r *RestDataServicesReconciler) SetStatus(ctx context.Context, req ctrl.Req
 	logr := log.FromContext(ctx).WithName("SetStatus")
 
 	// Fetch before Status Update
-	if err := r.Get(ctx, req.NamespacedName, ords); err != nil {
+	if err := r.getDefaulted(ctx, req.NamespacedName, ords); err != nil {
 		logr.Error(err, "Failed to re-fetch")
 		return err
 	}
 	var readyWorkload int32
 	var desiredWorkload int32
+	var configHash string
+	var workloadConfigHash string
+	progressingCondition := metav1.Condition{Type: typeProgressingORDS, Status: metav1.ConditionUnknown, Reason: "Reconciling", Message: "Workload not found"}
 	switch ords.Spec.WorkloadType {
 	//nolint:goconst
 	case "StatefulSet":
 		workload := &appsv1.StatefulSet{}
 		if err := r.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, workload); err != nil {
 			logr.Info("StatefulSet not ready")
+		} else {
+			progressingCondition = rolloutCondition(workload)
 		}
 		readyWorkload = workload.Status.ReadyReplicas
 		desiredWorkload = workload.Status.Replicas
+		configHash = workload.Annotations[configHashAnnotation]
+		workloadConfigHash = workload.Spec.Template.Annotations[configHashAnnotation]
 	//nolint:goconst
 	case "DaemonSet":
 		workload := &appsv1.DaemonSet{}
 		if err := r.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, workload); err != nil {
 			logr.Info("DaemonSet not ready")
+		} else {
+			progressingCondition = rolloutCondition(workload)
 		}
 		readyWorkload = workload.Status.NumberReady
 		desiredWorkload = workload.Status.DesiredNumberScheduled
+		configHash = workload.Annotations[configHashAnnotation]
+		workloadConfigHash = workload.Spec.Template.Annotations[configHashAnnotation]
 	default:
 		workload := &appsv1.Deployment{}
 		if err := r.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, workload); err != nil {
 			logr.Info("Deployment not ready")
+		} else {
+			progressingCondition = rolloutCondition(workload)
 		}
 		readyWorkload = workload.Status.ReadyReplicas
 		desiredWorkload = workload.Status.Replicas
+		configHash = workload.Annotations[configHashAnnotation]
+		workloadConfigHash = workload.Spec.Template.Annotations[configHashAnnotation]
 	}
 
 	var workloadStatus string
@@ -286,20 +484,28 @@ func (r *RestDataServicesReconciler) SetStatus(ctx context.Context, req ctrl.Req
 	} else {
 		workloadStatus = "Progressing"
 	}
+	if !ords.DeletionTimestamp.IsZero() {
+		workloadStatus = "Deleting"
+	}
 
 	mongoPort := int32(0)
 	if ords.Spec.GlobalSettings.MongoEnabled {
 		mongoPort = *ords.Spec.GlobalSettings.MongoPort
 	}
 
+	r.rolloutEvent(ords, progressingCondition)
+	meta.SetStatusCondition(&ords.Status.Conditions, progressingCondition)
 	meta.SetStatusCondition(&ords.Status.Conditions, statusCondition)
 	ords.Status.Status = workloadStatus
 	ords.Status.WorkloadType = ords.Spec.WorkloadType
-	ords.Status.ORDSVersion = strings.Split(ords.Spec.Image, ":")[1]
+	ords.Status.Replicas = desiredWorkload
+	ords.Status.Selector = labels.SelectorFromSet(getLabels(ords.Name)).String()
 	ords.Status.HTTPPort = ords.Spec.GlobalSettings.StandaloneHTTPPort
 	ords.Status.HTTPSPort = ords.Spec.GlobalSettings.StandaloneHTTPSPort
 	ords.Status.MongoPort = mongoPort
-	ords.Status.RestartRequired = RestartPods
+	ords.Status.ConfigHash = configHash
+	ords.Status.WorkloadConfigHash = workloadConfigHash
+	ords.Status.RestartRequired = configHash != workloadConfigHash
 	if err := r.Status().Update(ctx, ords); err != nil {
 		logr.Error(err, "Failed to update Status")
 		return err
@@ -310,9 +516,17 @@ func (r *RestDataServicesReconciler) SetStatus(ctx context.Context, req ctrl.Req
 /************************************************
  * ConfigMaps
  *************************************************/
-func (r *RestDataServicesReconciler) ConfigMapReconcile(ctx context.Context, ords *databasev1.RestDataServices, configMapName string, poolIndex int) (err error) {
+func (r *RestDataServicesReconciler) ConfigMapReconcile(ctx context.Context, ords *databasev1.RestDataServices, configMapName string, poolIndex int, rollbackConfig map[string]map[string]string, renderedConfig map[string]map[string]string) (err error) {
 	logr := log.FromContext(ctx).WithName("ConfigMapReconcile")
-	desiredConfigMap := r.ConfigMapDefine(ctx, ords, configMapName, poolIndex)
+	desiredConfigMap, err := r.ConfigMapDefine(ctx, ords, configMapName, poolIndex)
+	if err != nil {
+		return err
+	}
+	// The configuration of the revision rolled back to replaces the one rendered from the spec
+	if data, exists := rollbackConfig[configMapName]; exists {
+		desiredConfigMap.Data = data
+	}
+	renderedConfig[configMapName] = desiredConfigMap.Data
 
 	// Create if ConfigMap not found
 	definedConfigMap := &corev1.ConfigMap{}
@@ -322,7 +536,6 @@ func (r *RestDataServicesReconciler) ConfigMapReconcile(ctx context.Context, ord
 				return err
 			}
 			logr.Info("Created: " + configMapName)
-			RestartPods = true
 			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Create", "ConfigMap %s Created", configMapName)
 			// Requery for comparison
 			if err := r.Get(ctx, types.NamespacedName{Name: configMapName, Namespace: ords.Namespace}, definedConfigMap); err != nil {
@@ -332,107 +545,164 @@ func (r *RestDataServicesReconciler) ConfigMapReconcile(ctx context.Context, ord
 			return err
 		}
 	}
-	if !equality.Semantic.DeepEqual(definedConfigMap.Data, desiredConfigMap.Data) {
+	if !configMapDataEqual(definedConfigMap.Data, desiredConfigMap.Data) ||
+		definedConfigMap.Annotations[uninstallAnnotation] != desiredConfigMap.Annotations[uninstallAnnotation] {
 		if err = r.Update(ctx, desiredConfigMap); err != nil {
 			return err
 		}
 		logr.Info("Updated: " + configMapName)
-		RestartPods = true
 		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Update", "ConfigMap %s Updated", configMapName)
 	}
 	return nil
 }
 
 /************************************************
- * Secrets - TODO (Watch and set RestartPods)
- *************************************************/
-// func (r *RestDataServicesReconciler) SecretsReconcile(ctx context.Context, ords *databasev1.RestDataServices, poolIndex int) (err error) {
-// 	logr := log.FromContext(ctx).WithName("SecretsReconcile")
-// 	definedSecret := &corev1.Secret{}
-
-// 	// Want to set ownership on the Secret for watching; also detects if TNS_ADMIN is needed.
-// 	if ords.Spec.PoolSettings[i].DBSecret != nil {
-// 	}
-// 	if ords.Spec.PoolSettings[i].DBAdminUserSecret != nil {
-// 	}
-// 	if ords.Spec.PoolSettings[i].DBCDBAdminUserSecret != nil {
-// 	}
-// 	if ords.Spec.PoolSettings[i].TNSAdminSecret != nil {
-// 	}
-// 	if ords.Spec.PoolSettings[i].DBWalletSecret != nil {
-// 	}
-
-// 	if ords.Spec.PoolSettings[i].TNSAdminSecret != nil {
-// 		tnsSecretName := ords.Spec.PoolSettings[i].TNSAdminSecret.SecretName
-// 		definedSecret := &corev1.Secret{}
-// 		if err = r.Get(ctx, types.NamespacedName{Name: tnsSecretName, Namespace: ords.Namespace}, definedSecret); err != nil {
-// 			ojdbcPropertiesData, ok := secret.Data["ojdbc.properties"]
-// 			if ok {
-// 				if err = r.Update(ctx, desiredConfigMap); err != nil {
-// 					return err
-// 				}
-// 			}
-// 		}
-// 	}
-
-// 	return nil
-// }
-
-/************************************************
- * Workloads
+ * Secrets
  *************************************************/
-func (r *RestDataServicesReconciler) WorkloadReconcile(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices, kind string) (err error) {
-	logr := log.FromContext(ctx).WithName("WorkloadReconcile")
-	objectMeta := objectMetaDefine(ords, ords.Name)
-	selector := selectorDefine(ords)
-	template := podTemplateSpecDefine(ords)
-
-	var desiredWorkload client.Object
-	var desiredSpecHash string
-	var definedSpecHash string
+// SecretsReconcile records a hash of each referenced Secret key so that rotating a Secret
+// changes the configuration hash of the pods
+func (r *RestDataServicesReconciler) SecretsReconcile(ctx context.Context, ords *databasev1.RestDataServices, renderedConfig map[string]map[string]string) (err error) {
+	logr := log.FromContext(ctx).WithName("SecretsReconcile")
+
+	for secretName, secretKeys := range referencedSecrets(ords) {
+		definedSecret := &corev1.Secret{}
+		if err = r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: ords.Namespace}, definedSecret); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The Secret is watched; pods are rolled once it is created
+				logr.Info("Missing: Secret " + secretName)
+				r.Recorder.Eventf(ords, corev1.EventTypeWarning, "Missing", "Secret %s not found", secretName)
+				renderedConfig["Secret/"+secretName] = nil
+				continue
+			}
+			return err
+		}
+		secretHashes := make(map[string]string)
+		for key, value := range definedSecret.Data {
+			if secretKeys == nil || secretKeys[key] {
+				hash := sha256.Sum256(value)
+				secretHashes[key] = hex.EncodeToString(hash[:])
+			}
+		}
+		renderedConfig["Secret/"+secretName] = secretHashes
+	}
+	return nil
+}
 
-	switch kind {
-	case "StatefulSet":
-		desiredWorkload = &appsv1.StatefulSet{
-			ObjectMeta: objectMeta,
-			Spec: appsv1.StatefulSetSpec{
-				Replicas: &ords.Spec.Replicas,
-				Selector: &selector,
-				Template: template,
-			},
+// referencedSecrets returns the names of the Secrets referenced by the resource with the keys used;
+// nil keys when the whole Secret is mounted
+func referencedSecrets(ords *databasev1.RestDataServices) map[string]map[string]bool {
+	secrets := make(map[string]map[string]bool)
+	addSecret := func(name string, keys ...string) {
+		if name == "" {
+			return
 		}
-		desiredSpecHash = generateSpecHash(desiredWorkload.(*appsv1.StatefulSet).Spec)
-		desiredWorkload.(*appsv1.StatefulSet).ObjectMeta.Labels[specHashLabel] = desiredSpecHash
-	case "DaemonSet":
-		desiredWorkload = &appsv1.DaemonSet{
-			ObjectMeta: objectMeta,
-			Spec: appsv1.DaemonSetSpec{
-				Selector: &selector,
-				Template: template,
-			},
+		if _, exists := secrets[name]; exists && secrets[name] == nil {
+			return
 		}
-		desiredSpecHash = generateSpecHash(desiredWorkload.(*appsv1.DaemonSet).Spec)
-		desiredWorkload.(*appsv1.DaemonSet).ObjectMeta.Labels[specHashLabel] = desiredSpecHash
-	default:
-		desiredWorkload = &appsv1.Deployment{
-			ObjectMeta: objectMeta,
-			Spec: appsv1.DeploymentSpec{
-				Replicas: &ords.Spec.Replicas,
-				Selector: &selector,
-				Template: template,
-			},
+		if len(keys) == 0 {
+			secrets[name] = nil
+			return
+		}
+		if secrets[name] == nil {
+			secrets[name] = make(map[string]bool)
+		}
+		for _, key := range keys {
+			secrets[name][key] = true
 		}
-		desiredSpecHash = generateSpecHash(desiredWorkload.(*appsv1.Deployment).Spec)
-		desiredWorkload.(*appsv1.Deployment).ObjectMeta.Labels[specHashLabel] = desiredSpecHash
 	}
 
-	if err := ctrl.SetControllerReference(ords, desiredWorkload, r.Scheme); err != nil {
-		return err
+	if ords.Spec.GlobalSettings.CertSecret != nil {
+		addSecret(ords.Spec.GlobalSettings.CertSecret.SecretName,
+			ords.Spec.GlobalSettings.CertSecret.Certificate, ords.Spec.GlobalSettings.CertSecret.CertificateKey)
 	}
+	for _, pool := range ords.Spec.PoolSettings {
+		addSecret(pool.DBSecret.SecretName, pool.DBSecret.PasswordKey)
+		addSecret(pool.DBAdminUserSecret.SecretName, pool.DBAdminUserSecret.PasswordKey)
+		addSecret(pool.DBCDBAdminUserSecret.SecretName, pool.DBCDBAdminUserSecret.PasswordKey)
+		if pool.TNSAdminSecret != nil {
+			addSecret(pool.TNSAdminSecret.SecretName)
+		}
+		if pool.DBWalletSecret != nil {
+			addSecret(pool.DBWalletSecret.SecretName)
+		}
+	}
+	return secrets
+}
+
+// secretIndexValues returns the index values of the Secrets referenced by a resource
+func secretIndexValues(obj client.Object) []string {
+	ords, ok := obj.(*databasev1.RestDataServices)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for secretName := range referencedSecrets(ords) {
+		names = append(names, secretName)
+	}
+	return names
+}
+
+// secretToRequests maps a Secret to the resources in its namespace that reference it
+func (r *RestDataServicesReconciler) secretToRequests(ctx context.Context, secret client.Object) []reconcile.Request {
+	return r.indexToRequests(ctx, secretIndexKey, secret)
+}
+
+// initScriptIndexValues returns the index value of the init script ConfigMap of a resource
+func initScriptIndexValues(obj client.Object) []string {
+	ords, ok := obj.(*databasev1.RestDataServices)
+	if !ok || ords.Spec.InitScript == nil {
+		return nil
+	}
+	return []string{ords.Spec.InitScript.Name}
+}
+
+// initScriptToRequests maps a ConfigMap to the resources in its namespace using it as init script
+func (r *RestDataServicesReconciler) initScriptToRequests(ctx context.Context, configMap client.Object) []reconcile.Request {
+	return r.indexToRequests(ctx, initScriptIndexKey, configMap)
+}
+
+// indexToRequests maps an object to the resources in its namespace indexed by its name
+func (r *RestDataServicesReconciler) indexToRequests(ctx context.Context, indexKey string, obj client.Object) []reconcile.Request {
+	ordsList := &databasev1.RestDataServicesList{}
+	if err := r.List(ctx, ordsList, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{indexKey: obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list resources referencing "+obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(ordsList.Items))
+	for _, ords := range ordsList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}})
+	}
+	return requests
+}
+
+// podToRequests maps a Workload pod to its resource, to report the results of its init container
+func podToRequests(ctx context.Context, pod client.Object) []reconcile.Request {
+	name := pod.GetLabels()["app.kubernetes.io/instance"]
+	if name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: pod.GetNamespace()}}}
+}
 
-	definedWorkload := reflect.New(reflect.TypeOf(desiredWorkload).Elem()).Interface().(client.Object)
+/************************************************
+ * Workloads
+ *************************************************/
+// WorkloadReconcile returns true when the pods are running with a configuration other than configHash.
+// Pods are only restarted for a configuration change when forceRestart is set or the Workload is changing anyway.
+func (r *RestDataServicesReconciler) WorkloadReconcile(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices, kind string, configHash string) (restartRequired bool, err error) {
+	logr := log.FromContext(ctx).WithName("WorkloadReconcile")
+
+	definedWorkload := workloadObject(kind)
 	if err = r.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, definedWorkload); err != nil {
 		if apierrors.IsNotFound(err) {
+			desiredWorkload, _ := workloadDefine(ords, kind, configHash, configHash)
+			if autoscaled(ords) {
+				setWorkloadReplicas(desiredWorkload, ords.Spec.Autoscaling.MinReplicas)
+			}
+			if err := ctrl.SetControllerReference(ords, desiredWorkload, r.Scheme); err != nil {
+				return false, err
+			}
 			if err := r.Create(ctx, desiredWorkload); err != nil {
 				condition := metav1.Condition{
 					Type:    typeAvailableORDS,
@@ -441,53 +711,43 @@ func (r *RestDataServicesReconciler) WorkloadReconcile(ctx context.Context, req
 					Message: fmt.Sprintf("Failed to create %s for the custom resource (%s): (%s)", kind, ords.Name, err),
 				}
 				if statusErr := r.SetStatus(ctx, req, ords, condition); statusErr != nil {
-					return statusErr
+					return false, statusErr
 				}
-				return err
+				return false, err
 			}
 			logr.Info("Created: " + kind)
-			RestartPods = false
 			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Create", "Created %s", kind)
-			return nil
+			return false, nil
 		} else {
-			return err
+			return false, err
 		}
 	}
 
-	definedLabelsField := reflect.ValueOf(definedWorkload).Elem().FieldByName("ObjectMeta").FieldByName("Labels")
-	if definedLabelsField.IsValid() {
-		specHashValue := definedLabelsField.MapIndex(reflect.ValueOf(specHashLabel))
-		if specHashValue.IsValid() {
-			definedSpecHash = specHashValue.Interface().(string)
-		} else {
-			return err
-		}
+	// Keep the configuration hash the pods were started with, unless they are to be restarted
+	definedSpecHash := definedWorkload.GetLabels()[specHashLabel]
+	workloadConfigHash := workloadPodTemplate(definedWorkload).Annotations[configHashAnnotation]
+	desiredWorkload, desiredSpecHash := workloadDefine(ords, kind, configHash, workloadConfigHash)
+	if workloadConfigHash != configHash && (ords.Spec.ForceRestart || desiredSpecHash != definedSpecHash) {
+		logr.Info("Cycling: " + kind)
+		workloadConfigHash = configHash
+		desiredWorkload, desiredSpecHash = workloadDefine(ords, kind, configHash, workloadConfigHash)
+		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Restart", "Restarted %s", kind)
+	}
+	if autoscaled(ords) {
+		setWorkloadReplicas(desiredWorkload, workloadReplicas(definedWorkload))
+	}
+	if err := ctrl.SetControllerReference(ords, desiredWorkload, r.Scheme); err != nil {
+		return false, err
 	}
 
-	if desiredSpecHash != definedSpecHash {
+	if desiredSpecHash != definedSpecHash || definedWorkload.GetAnnotations()[configHashAnnotation] != configHash {
 		logr.Info("Syncing Workload " + kind + " with new configuration")
 		if err := r.Client.Update(ctx, desiredWorkload); err != nil {
-			return err
+			return false, err
 		}
-		RestartPods = true
 		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Update", "Updated %s", kind)
 	}
-
-	if RestartPods && ords.Spec.ForceRestart {
-		logr.Info("Cycling: " + kind)
-		labelsField := reflect.ValueOf(desiredWorkload).Elem().FieldByName("Spec").FieldByName("Template").FieldByName("ObjectMeta").FieldByName("Labels")
-		if labelsField.IsValid() {
-			labels := labelsField.Interface().(map[string]string)
-			labels["configMapChanged"] = time.Now().Format("20060102T150405Z")
-			labelsField.Set(reflect.ValueOf(labels))
-			if err := r.Update(ctx, desiredWorkload); err != nil {
-				return err
-			}
-			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Restart", "Restarted %s", kind)
-			RestartPods = false
-		}
-	}
-	return nil
+	return workloadConfigHash != configHash, nil
 }
 
 // Service
@@ -508,53 +768,159 @@ func (r *RestDataServicesReconciler) ServiceReconcile(ctx context.Context, ords
 			}
 			logr.Info("Created: Service")
 			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Create", "Service %s Created", ords.Name)
-			// Requery for comparison
-			if err := r.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, definedService); err != nil {
-				return err
+			return nil
+		}
+		return err
+	}
+
+	// Keep the node ports allocated by Kubernetes, unless specified
+	if serviceExternal(desiredService.Spec.Type) {
+		for i, desiredPort := range desiredService.Spec.Ports {
+			for _, definedPort := range definedService.Spec.Ports {
+				if desiredPort.NodePort == 0 && definedPort.Name == desiredPort.Name {
+					desiredService.Spec.Ports[i].NodePort = definedPort.NodePort
+				}
 			}
-		} else {
-			return err
+		}
+		if desiredService.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyLocal {
+			desiredService.Spec.HealthCheckNodePort = definedService.Spec.HealthCheckNodePort
 		}
 	}
+	if !serviceDrifted(desiredService, definedService) {
+		return nil
+	}
 
-	deisredPortCount := len(desiredService.Spec.Ports)
-	definedPortCount := len(definedService.Spec.Ports)
+	// Update the defined Service, keeping the fields set by Kubernetes and the metadata set by others
+	service := definedService.DeepCopy()
+	var managedMetadata serviceMetadata
+	_ = json.Unmarshal([]byte(definedService.Annotations[serviceMetadataAnnotation]), &managedMetadata)
+	service.Labels = metadataMerge(service.Labels, desiredService.Labels, managedMetadata.Labels)
+	service.Annotations = metadataMerge(service.Annotations, desiredService.Annotations, managedMetadata.Annotations)
+	service.Spec.Type = desiredService.Spec.Type
+	service.Spec.Selector = desiredService.Spec.Selector
+	service.Spec.Ports = desiredService.Spec.Ports
+	service.Spec.SessionAffinity = desiredService.Spec.SessionAffinity
+	service.Spec.ExternalTrafficPolicy = desiredService.Spec.ExternalTrafficPolicy
+	service.Spec.HealthCheckNodePort = desiredService.Spec.HealthCheckNodePort
+	service.Spec.LoadBalancerSourceRanges = desiredService.Spec.LoadBalancerSourceRanges
+	if !serviceExternal(service.Spec.Type) {
+		service.Spec.AllocateLoadBalancerNodePorts = nil
+	}
+	if err := r.Update(ctx, service); err != nil {
+		return err
+	}
+	logr.Info("Updated: Service")
+	r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Update", "Service %s Updated", ords.Name)
+	return nil
+}
 
-	if deisredPortCount != definedPortCount {
-		if err := r.Update(ctx, desiredService); err != nil {
-			return err
+// serviceMetadata lists the labels and annotations set on the Service by the operator, to remove them
+// once no longer specified while keeping those set by others, such as cloud controllers
+type serviceMetadata struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// serviceDrifted returns true when the defined Service differs from the desired Service in any field set by the operator
+func serviceDrifted(desired *corev1.Service, defined *corev1.Service) bool {
+	if desired.Annotations[serviceMetadataAnnotation] != defined.Annotations[serviceMetadataAnnotation] {
+		return true
+	}
+	for key, value := range desired.Labels {
+		if definedValue, ok := defined.Labels[key]; !ok || definedValue != value {
+			return true
+		}
+	}
+	for key, value := range desired.Annotations {
+		if definedValue, ok := defined.Annotations[key]; !ok || definedValue != value {
+			return true
+		}
+	}
+	desiredSpec, definedSpec := desired.Spec, defined.Spec
+	if desiredSpec.Type != definedSpec.Type ||
+		desiredSpec.SessionAffinity != definedSpec.SessionAffinity ||
+		desiredSpec.ExternalTrafficPolicy != definedSpec.ExternalTrafficPolicy ||
+		desiredSpec.HealthCheckNodePort != definedSpec.HealthCheckNodePort ||
+		!equality.Semantic.DeepEqual(desiredSpec.Selector, definedSpec.Selector) ||
+		strings.Join(desiredSpec.LoadBalancerSourceRanges, ",") != strings.Join(definedSpec.LoadBalancerSourceRanges, ",") ||
+		len(desiredSpec.Ports) != len(definedSpec.Ports) {
+		return true
+	}
+	for i, desiredPort := range desiredSpec.Ports {
+		definedPort := definedSpec.Ports[i]
+		if desiredPort.Name != definedPort.Name || desiredPort.Port != definedPort.Port ||
+			desiredPort.Protocol != definedPort.Protocol || desiredPort.TargetPort != definedPort.TargetPort ||
+			desiredPort.NodePort != definedPort.NodePort {
+			return true
 		}
 	}
+	return false
+}
 
-	for _, existingPort := range definedService.Spec.Ports {
-		if existingPort.Name == serviceHTTPPortName {
-			if existingPort.Port != HTTPport {
-				if err := r.Update(ctx, desiredService); err != nil {
-					return err
-				}
-				logr.Info("Updated HTTP Service Port: " + existingPort.Name)
-				r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Update", "Service HTTP Port %s Updated", existingPort.Name)
-			}
+// metadataMerge returns the defined labels or annotations with the desired applied and the previously
+// managed, no longer desired, removed
+func metadataMerge(defined map[string]string, desired map[string]string, managed []string) map[string]string {
+	merged := make(map[string]string)
+	for key, value := range defined {
+		merged[key] = value
+	}
+	for _, key := range managed {
+		delete(merged, key)
+	}
+	for key, value := range desired {
+		merged[key] = value
+	}
+	return merged
+}
+
+// serviceExternal returns true for Service types exposed outside the cluster, on node ports
+func serviceExternal(serviceType corev1.ServiceType) bool {
+	return serviceType == corev1.ServiceTypeNodePort || serviceType == corev1.ServiceTypeLoadBalancer
+}
+
+// ownedReconcile creates the desired object, updates it when its spec hash changes, or deletes the
+// defined object when there is no desired object
+func (r *RestDataServicesReconciler) ownedReconcile(ctx context.Context, ords *databasev1.RestDataServices, kind string, definedObject client.Object, desiredObject client.Object, desiredSpecHash string) error {
+	logr := log.FromContext(ctx).WithName("OwnedReconcile")
+
+	err := r.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, definedObject)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	if desiredObject == nil {
+		if !exists || !metav1.IsControlledBy(definedObject, ords) {
+			return nil
 		}
-		if existingPort.Name == serviceHTTPSPortName {
-			if existingPort.Port != HTTPSport {
-				if err := r.Update(ctx, desiredService); err != nil {
-					return err
-				}
-				logr.Info("Updated HTTPS Service Port: " + existingPort.Name)
-				r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Update", "Service HTTPS Port %s Updated", existingPort.Name)
-			}
+		if err := r.Delete(ctx, definedObject); client.IgnoreNotFound(err) != nil {
+			return err
 		}
-		if existingPort.Name == serviceMongoPortName {
-			if existingPort.Port != MongoPort {
-				if err := r.Update(ctx, desiredService); err != nil {
-					return err
-				}
-				logr.Info("Updated Mongo Service Port: " + existingPort.Name)
-				r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Update", "Service Mongo Port %s Updated", existingPort.Name)
-			}
+		logr.Info("Deleted: " + kind)
+		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "%s %s Deleted", kind, ords.Name)
+		return nil
+	}
+
+	if err := ctrl.SetControllerReference(ords, desiredObject, r.Scheme); err != nil {
+		return err
+	}
+	if !exists {
+		if err := r.Create(ctx, desiredObject); err != nil {
+			return err
 		}
+		logr.Info("Created: " + kind)
+		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Create", "%s %s Created", kind, ords.Name)
+		return nil
+	}
+	if definedObject.GetLabels()[specHashLabel] == desiredSpecHash {
+		return nil
 	}
+	desiredObject.SetResourceVersion(definedObject.GetResourceVersion())
+	if err := r.Update(ctx, desiredObject); err != nil {
+		return err
+	}
+	logr.Info("Updated: " + kind)
+	r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Update", "%s %s Updated", kind, ords.Name)
 	return nil
 }
 
@@ -580,8 +946,121 @@ func selectorDefine(ords *databasev1.RestDataServices) metav1.LabelSelector {
 	}
 }
 
+// workloadDefine returns the Workload of kind and its spec hash.  The Workload is annotated with the
+// configHash of the rendered configuration and its pods with the podConfigHash they are started with.
+func workloadDefine(ords *databasev1.RestDataServices, kind string, configHash string, podConfigHash string) (client.Object, string) {
+	objectMeta := objectMetaDefine(ords, ords.Name)
+	objectMeta.Annotations = map[string]string{configHashAnnotation: configHash}
+	selector := selectorDefine(ords)
+	template := podTemplateSpecDefine(ords)
+	// The replicas are left to the HorizontalPodAutoscaler while autoscaling
+	replicas := &ords.Spec.Replicas
+	if autoscaled(ords) {
+		replicas = nil
+	}
+	rollout := ords.Spec.Rollout
+	if podConfigHash != "" {
+		template.ObjectMeta.Annotations[configHashAnnotation] = podConfigHash
+	}
+
+	var specHash string
+	var workload client.Object
+	switch kind {
+	case "StatefulSet":
+		spec := appsv1.StatefulSetSpec{
+			Replicas:             replicas,
+			Selector:             &selector,
+			Template:             template,
+			UpdateStrategy:       statefulSetStrategyDefine(rollout),
+			MinReadySeconds:      rollout.MinReadySeconds,
+			RevisionHistoryLimit: rollout.RevisionHistoryLimit,
+		}
+		specHash = generateSpecHash(spec)
+		workload = &appsv1.StatefulSet{ObjectMeta: objectMeta, Spec: spec}
+	case "DaemonSet":
+		spec := appsv1.DaemonSetSpec{
+			Selector:             &selector,
+			Template:             template,
+			UpdateStrategy:       daemonSetStrategyDefine(rollout),
+			MinReadySeconds:      rollout.MinReadySeconds,
+			RevisionHistoryLimit: rollout.RevisionHistoryLimit,
+		}
+		specHash = generateSpecHash(spec)
+		workload = &appsv1.DaemonSet{ObjectMeta: objectMeta, Spec: spec}
+	default:
+		spec := appsv1.DeploymentSpec{
+			Replicas:                replicas,
+			Selector:                &selector,
+			Template:                template,
+			Strategy:                deploymentStrategyDefine(rollout),
+			MinReadySeconds:         rollout.MinReadySeconds,
+			RevisionHistoryLimit:    rollout.RevisionHistoryLimit,
+			ProgressDeadlineSeconds: rollout.ProgressDeadlineSeconds,
+		}
+		specHash = generateSpecHash(spec)
+		workload = &appsv1.Deployment{ObjectMeta: objectMeta, Spec: spec}
+	}
+	workload.GetLabels()[specHashLabel] = specHash
+	return workload, specHash
+}
+
+// workloadObject returns an empty Workload of kind
+func workloadObject(kind string) client.Object {
+	switch kind {
+	case "StatefulSet":
+		return &appsv1.StatefulSet{}
+	case "DaemonSet":
+		return &appsv1.DaemonSet{}
+	default:
+		return &appsv1.Deployment{}
+	}
+}
+
+// workloadPodTemplate returns the pod template of a Workload
+func workloadPodTemplate(workload client.Object) *corev1.PodTemplateSpec {
+	switch w := workload.(type) {
+	case *appsv1.StatefulSet:
+		return &w.Spec.Template
+	case *appsv1.DaemonSet:
+		return &w.Spec.Template
+	case *appsv1.Deployment:
+		return &w.Spec.Template
+	default:
+		return &corev1.PodTemplateSpec{}
+	}
+}
+
+// workloadReplicas returns the replicas of a Deployment or StatefulSet
+func workloadReplicas(workload client.Object) *int32 {
+	switch w := workload.(type) {
+	case *appsv1.StatefulSet:
+		return w.Spec.Replicas
+	case *appsv1.Deployment:
+		return w.Spec.Replicas
+	default:
+		return nil
+	}
+}
+
+// setWorkloadReplicas sets the replicas of a Deployment or StatefulSet
+func setWorkloadReplicas(workload client.Object, replicas *int32) {
+	switch w := workload.(type) {
+	case *appsv1.StatefulSet:
+		w.Spec.Replicas = replicas
+	case *appsv1.Deployment:
+		w.Spec.Replicas = replicas
+	}
+}
+
 func podTemplateSpecDefine(ords *databasev1.RestDataServices) corev1.PodTemplateSpec {
-	labels := getLabels(ords.Name)
+	// The selector labels are set last so that the podTemplate labels cannot break the selector
+	labels := make(map[string]string)
+	for key, value := range ords.Spec.PodTemplate.Labels {
+		labels[key] = value
+	}
+	for key, value := range getLabels(ords.Name) {
+		labels[key] = value
+	}
 	specVolumes, specVolumeMounts := VolumesDefine(ords)
 
 	envPorts := []corev1.ContainerPort{
@@ -607,10 +1086,12 @@ func podTemplateSpecDefine(ords *databasev1.RestDataServices) corev1.PodTemplate
 	podSpecTemplate :=
 		corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
+				Labels:      labels,
+				Annotations: make(map[string]string),
 			},
 			Spec: corev1.PodSpec{
-				Volumes: specVolumes,
+				Volumes:          specVolumes,
+				ImagePullSecrets: ords.Spec.ImagePullSecrets,
 				SecurityContext: &corev1.PodSecurityContext{
 					RunAsNonRoot: &[]bool{true}[0],
 					FSGroup:      &[]int64{54321}[0],
@@ -621,7 +1102,7 @@ func podTemplateSpecDefine(ords *databasev1.RestDataServices) corev1.PodTemplate
 				InitContainers: []corev1.Container{{
 					Image:           ords.Spec.Image,
 					Name:            ords.Name + "-init",
-					ImagePullPolicy: corev1.PullIfNotPresent,
+					ImagePullPolicy: ords.Spec.ImagePullPolicy,
 					SecurityContext: securityContextDefine(),
 					Command:         []string{"sh", "-c", ordsSABase + "/bin/init_script.sh"},
 					Env:             envDefine(ords, true),
@@ -630,19 +1111,70 @@ func podTemplateSpecDefine(ords *databasev1.RestDataServices) corev1.PodTemplate
 				Containers: []corev1.Container{{
 					Image:           ords.Spec.Image,
 					Name:            ords.Name,
-					ImagePullPolicy: corev1.PullIfNotPresent,
+					ImagePullPolicy: ords.Spec.ImagePullPolicy,
 					SecurityContext: securityContextDefine(),
 					Ports:           envPorts,
 					//Command: []string{"sh", "-c", "tail -f /dev/null"},
-					Command:      []string{"/bin/bash", "-c", "ords --config $ORDS_CONFIG serve --apex-images /opt/oracle/apex/$APEX_VER/images --debug"},
-					Env:          envDefine(ords, false),
-					VolumeMounts: specVolumeMounts,
+					Command:        []string{"/bin/bash", "-c", "ords --config $ORDS_CONFIG serve --apex-images /opt/oracle/apex/$APEX_VER/images --debug"},
+					Env:            envDefine(ords, false),
+					VolumeMounts:   specVolumeMounts,
+					ReadinessProbe: probeDefine(ords, ords.Spec.PodTemplate.ReadinessProbe, 10, 5, 3),
+					LivenessProbe:  probeDefine(ords, ords.Spec.PodTemplate.LivenessProbe, 30, 10, 3),
+					StartupProbe:   probeDefine(ords, ords.Spec.PodTemplate.StartupProbe, 10, 5, 60),
 				}}},
 		}
+	for key, value := range ords.Spec.PodTemplate.Annotations {
+		podSpecTemplate.ObjectMeta.Annotations[key] = value
+	}
+	podSchedulingDefine(ords, &podSpecTemplate.Spec)
 
 	return podSpecTemplate
 }
 
+// probeDefine returns the probe override, or an HTTP GET of the context path over HTTPS when it is forced,
+// adding the httpsHeaderCheck header for ORDS to treat the probe as secure
+func probeDefine(ords *databasev1.RestDataServices, override *corev1.Probe, periodSeconds int32, timeoutSeconds int32, failureThreshold int32) *corev1.Probe {
+	if override != nil {
+		return override
+	}
+	globalSettings := ords.Spec.GlobalSettings
+	httpGet := &corev1.HTTPGetAction{
+		Path:   strings.TrimSuffix(globalSettings.StandaloneContextPath, "/") + "/",
+		Port:   intstr.FromString(targetHTTPPortName),
+		Scheme: corev1.URISchemeHTTP,
+	}
+	if globalSettings.SecurityForceHTTPS {
+		httpGet.Port = intstr.FromString(targetHTTPSPortName)
+		httpGet.Scheme = corev1.URISchemeHTTPS
+	}
+	if name, value, found := strings.Cut(httpsHeaderCheck(ords), ":"); found {
+		httpGet.HTTPHeaders = []corev1.HTTPHeader{{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)}}
+	}
+	return &corev1.Probe{
+		ProbeHandler:     corev1.ProbeHandler{HTTPGet: httpGet},
+		PeriodSeconds:    periodSeconds,
+		TimeoutSeconds:   timeoutSeconds,
+		FailureThreshold: failureThreshold,
+	}
+}
+
+// podSchedulingDefine applies the scheduling and resources of the podTemplate to a PodSpec
+func podSchedulingDefine(ords *databasev1.RestDataServices, podSpec *corev1.PodSpec) {
+	podTemplate := ords.Spec.PodTemplate
+	podSpec.NodeSelector = podTemplate.NodeSelector
+	podSpec.Tolerations = podTemplate.Tolerations
+	podSpec.Affinity = podTemplate.Affinity
+	podSpec.TopologySpreadConstraints = podTemplate.TopologySpreadConstraints
+	podSpec.PriorityClassName = podTemplate.PriorityClassName
+	podSpec.ServiceAccountName = podTemplate.ServiceAccountName
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Resources = podTemplate.Resources
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Resources = podTemplate.Resources
+	}
+}
+
 // Volumes
 func VolumesDefine(ords *databasev1.RestDataServices) ([]corev1.Volume, []corev1.VolumeMount) {
 	// Initialize the slice to hold specifications
@@ -684,45 +1216,46 @@ func VolumesDefine(ords *databasev1.RestDataServices) ([]corev1.Volume, []corev1
 	}
 
 	// Build volume specifications for each pool in poolSettings
-	definedWalletSecret := make(map[string]bool)
-	definedTNSSecret := make(map[string]bool)
+	definedVolumes := make(map[string]bool)
 	for i := 0; i < len(ords.Spec.PoolSettings); i++ {
-		poolName := strings.ToLower(ords.Spec.PoolSettings[i].PoolName)
+		poolVolumes, poolVolumeMounts := poolVolumesDefine(ords, ords.Spec.PoolSettings[i])
+		for _, poolVolume := range poolVolumes {
+			// Only create the (Secret) volume once
+			if !definedVolumes[poolVolume.Name] {
+				volumes = append(volumes, poolVolume)
+				definedVolumes[poolVolume.Name] = true
+			}
+		}
+		volumeMounts = append(volumeMounts, poolVolumeMounts...)
+	}
+	return volumes, volumeMounts
+}
 
-		poolWalletName := "sa-wallet-" + poolName
-		poolWalletVolume := volumeBuild(poolWalletName, "EmptyDir")
-		poolWalletVolumeMount := volumeMountBuild(poolWalletName, ordsSABase+"/config/databases/"+poolName+"/wallet/", false)
+// poolVolumesDefine returns the volumes and mounts of a pool
+func poolVolumesDefine(ords *databasev1.RestDataServices, pool *databasev1.PoolSettings) ([]corev1.Volume, []corev1.VolumeMount) {
+	poolName := strings.ToLower(pool.PoolName)
 
-		poolConfigName := ords.Name + "-" + poolConfigPreName + poolName
-		poolConfigVolume := volumeBuild(poolConfigName, "ConfigMap")
-		poolConfigVolumeMount := volumeMountBuild(poolConfigName, ordsSABase+"/config/databases/"+poolName+"/", true)
+	poolWalletName := "sa-wallet-" + poolName
+	poolWalletVolume := volumeBuild(poolWalletName, "EmptyDir")
+	poolWalletVolumeMount := volumeMountBuild(poolWalletName, ordsSABase+"/config/databases/"+poolName+"/wallet/", false)
 
-		volumes = append(volumes, poolWalletVolume, poolConfigVolume)
-		volumeMounts = append(volumeMounts, poolWalletVolumeMount, poolConfigVolumeMount)
+	poolConfigName := ords.Name + "-" + poolConfigPreName + poolName
+	poolConfigVolume := volumeBuild(poolConfigName, "ConfigMap")
+	poolConfigVolumeMount := volumeMountBuild(poolConfigName, ordsSABase+"/config/databases/"+poolName+"/", true)
 
-		if ords.Spec.PoolSettings[i].DBWalletSecret != nil {
-			walletSecretName := ords.Spec.PoolSettings[i].DBWalletSecret.SecretName
-			if !definedWalletSecret[walletSecretName] {
-				// Only create the volume once
-				poolDBWalletVolume := volumeBuild(walletSecretName, "Secret")
-				volumes = append(volumes, poolDBWalletVolume)
-				definedWalletSecret[walletSecretName] = true
-			}
-			poolDBWalletVolumeMount := volumeMountBuild(walletSecretName, ordsSABase+"/config/databases/"+poolName+"/network/admin/", true)
-			volumeMounts = append(volumeMounts, poolDBWalletVolumeMount)
-		}
+	volumes := []corev1.Volume{poolWalletVolume, poolConfigVolume}
+	volumeMounts := []corev1.VolumeMount{poolWalletVolumeMount, poolConfigVolumeMount}
 
-		if ords.Spec.PoolSettings[i].TNSAdminSecret != nil {
-			tnsSecretName := ords.Spec.PoolSettings[i].TNSAdminSecret.SecretName
-			if !definedTNSSecret[tnsSecretName] {
-				// Only create the volume once
-				poolTNSAdminVolume := volumeBuild(tnsSecretName, "Secret")
-				volumes = append(volumes, poolTNSAdminVolume)
-				definedTNSSecret[tnsSecretName] = true
-			}
-			poolTNSAdminVolumeMount := volumeMountBuild(tnsSecretName, ordsSABase+"/config/databases/"+poolName+"/network/admin/", true)
-			volumeMounts = append(volumeMounts, poolTNSAdminVolumeMount)
-		}
+	if pool.DBWalletSecret != nil {
+		walletSecretName := pool.DBWalletSecret.SecretName
+		volumes = append(volumes, volumeBuild(walletSecretName, "Secret"))
+		volumeMounts = append(volumeMounts, volumeMountBuild(walletSecretName, ordsSABase+"/config/databases/"+poolName+"/network/admin/", true))
+	}
+
+	if pool.TNSAdminSecret != nil {
+		tnsSecretName := pool.TNSAdminSecret.SecretName
+		volumes = append(volumes, volumeBuild(tnsSecretName, "Secret"))
+		volumeMounts = append(volumeMounts, volumeMountBuild(tnsSecretName, ordsSABase+"/config/databases/"+poolName+"/network/admin/", true))
 	}
 	return volumes, volumeMounts
 }
@@ -777,6 +1310,8 @@ func volumeBuild(name string, source string, mode ...int32) corev1.Volume {
 // Service
 func (r *RestDataServicesReconciler) ServiceDefine(ctx context.Context, ords *databasev1.RestDataServices, HTTPport int32, HTTPSport int32, MongoPort int32) *corev1.Service {
 	labels := getLabels(ords.Name)
+	serviceSettings := ords.Spec.Service
+	external := serviceExternal(serviceSettings.Type)
 
 	servicePorts := []corev1.ServicePort{
 		{
@@ -792,6 +1327,10 @@ func (r *RestDataServicesReconciler) ServiceDefine(ctx context.Context, ords *da
 			TargetPort: intstr.FromString(targetHTTPSPortName),
 		},
 	}
+	if external {
+		servicePorts[0].NodePort = serviceSettings.NodePorts.HTTP
+		servicePorts[1].NodePort = serviceSettings.NodePorts.HTTPS
+	}
 
 	if ords.Spec.GlobalSettings.MongoEnabled {
 		mongoServicePort := corev1.ServicePort{
@@ -800,17 +1339,48 @@ func (r *RestDataServicesReconciler) ServiceDefine(ctx context.Context, ords *da
 			Port:       MongoPort,
 			TargetPort: intstr.FromString(targetMongoPortName),
 		}
+		if external {
+			mongoServicePort.NodePort = serviceSettings.NodePorts.Mongo
+		}
 		servicePorts = append(servicePorts, mongoServicePort)
 	}
 
+	// The operator's labels are set last so that the Service labels cannot override them
 	objectMeta := objectMetaDefine(ords, ords.Name)
+	objectMeta.Labels = make(map[string]string)
+	for key, value := range serviceSettings.Labels {
+		objectMeta.Labels[key] = value
+	}
+	for key, value := range labels {
+		objectMeta.Labels[key] = value
+	}
+	objectMeta.Annotations = make(map[string]string)
+	for key, value := range serviceSettings.Annotations {
+		objectMeta.Annotations[key] = value
+	}
+	managedMetadata := serviceMetadata{Labels: sortedKeys(objectMeta.Labels), Annotations: sortedKeys(objectMeta.Annotations)}
+	metadata, _ := json.Marshal(managedMetadata)
+	objectMeta.Annotations[serviceMetadataAnnotation] = string(metadata)
+
 	def := &corev1.Service{
 		ObjectMeta: objectMeta,
 		Spec: corev1.ServiceSpec{
-			Selector: labels,
-			Ports:    servicePorts,
+			Type:            serviceSettings.Type,
+			Selector:        labels,
+			Ports:           servicePorts,
+			SessionAffinity: serviceSettings.SessionAffinity,
 		},
 	}
+	if external {
+		// Kubernetes defaults the policy to Cluster; set it for the comparison with the defined Service
+		def.Spec.ExternalTrafficPolicy = serviceSettings.ExternalTrafficPolicy
+		if def.Spec.ExternalTrafficPolicy == "" {
+			def.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyCluster
+		}
+	}
+	if serviceSettings.Type == corev1.ServiceTypeLoadBalancer {
+		def.Spec.LoadBalancerSourceRanges = serviceSettings.LoadBalancerSourceRanges
+	}
 
 	// Set the ownerRef
 	if err := ctrl.SetControllerReference(ords, def, r.Scheme); err != nil {
@@ -854,56 +1424,62 @@ func envDefine(ords *databasev1.RestDataServices, initContainer bool) []corev1.E
 	}
 	if initContainer {
 		for i := 0; i < len(ords.Spec.PoolSettings); i++ {
-			poolName := strings.ReplaceAll(strings.ToLower(ords.Spec.PoolSettings[i].PoolName), "-", "_")
-			dbSecret := corev1.EnvVar{
-				Name: poolName + "_dbsecret",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: ords.Spec.PoolSettings[i].DBSecret.SecretName,
-						},
-						Key: ords.Spec.PoolSettings[i].DBSecret.PasswordKey,
-					},
+			envVarSecrets = append(envVarSecrets, poolEnvDefine(ords.Spec.PoolSettings[i])...)
+		}
+	}
+	return envVarSecrets
+}
+
+// poolEnvDefine returns the init container variables of a pool; these are read by ords_init.sh
+func poolEnvDefine(pool *databasev1.PoolSettings) []corev1.EnvVar {
+	poolName := strings.ReplaceAll(strings.ToLower(pool.PoolName), "-", "_")
+	dbSecret := corev1.EnvVar{
+		Name: poolName + "_dbsecret",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: pool.DBSecret.SecretName,
 				},
-			}
-			envVarSecrets = append(envVarSecrets, dbSecret)
-			if ords.Spec.PoolSettings[i].DBAdminUserSecret.SecretName != "" {
-				autoUpgradeORDSEnv := corev1.EnvVar{
-					Name:  poolName + "_autoupgrade_ords",
-					Value: strconv.FormatBool(ords.Spec.PoolSettings[i].AutoUpgradeORDS),
-				}
-				autoUpgradeAPEXEnv := corev1.EnvVar{
-					Name:  poolName + "_autoupgrade_apex",
-					Value: strconv.FormatBool(ords.Spec.PoolSettings[i].AutoUpgradeAPEX),
-				}
-				dbAdminUserSecret := corev1.EnvVar{
-					Name: poolName + "_dbadminusersecret",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: ords.Spec.PoolSettings[i].DBAdminUserSecret.SecretName,
-							},
-							Key: ords.Spec.PoolSettings[i].DBAdminUserSecret.PasswordKey,
-						},
+				Key: pool.DBSecret.PasswordKey,
+			},
+		},
+	}
+	envVarSecrets := []corev1.EnvVar{dbSecret}
+	if pool.DBAdminUserSecret.SecretName != "" {
+		autoUpgradeORDSEnv := corev1.EnvVar{
+			Name:  poolName + "_autoupgrade_ords",
+			Value: strconv.FormatBool(pool.AutoUpgradeORDS),
+		}
+		autoUpgradeAPEXEnv := corev1.EnvVar{
+			Name:  poolName + "_autoupgrade_apex",
+			Value: strconv.FormatBool(pool.AutoUpgradeAPEX),
+		}
+		dbAdminUserSecret := corev1.EnvVar{
+			Name: poolName + "_dbadminusersecret",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: pool.DBAdminUserSecret.SecretName,
 					},
-				}
-				envVarSecrets = append(envVarSecrets, dbAdminUserSecret, autoUpgradeORDSEnv, autoUpgradeAPEXEnv)
-			}
-			if ords.Spec.PoolSettings[i].DBCDBAdminUserSecret.SecretName != "" {
-				dbCDBAdminUserSecret := corev1.EnvVar{
-					Name: poolName + "_dbcdbadminusersecret",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: ords.Spec.PoolSettings[i].DBCDBAdminUserSecret.SecretName,
-							},
-							Key: ords.Spec.PoolSettings[i].DBCDBAdminUserSecret.PasswordKey,
-						},
+					Key: pool.DBAdminUserSecret.PasswordKey,
+				},
+			},
+		}
+		envVarSecrets = append(envVarSecrets, dbAdminUserSecret, autoUpgradeORDSEnv, autoUpgradeAPEXEnv)
+	}
+	if pool.DBCDBAdminUserSecret.SecretName != "" {
+		dbCDBAdminUserSecret := corev1.EnvVar{
+			Name: poolName + "_dbcdbadminusersecret",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: pool.DBCDBAdminUserSecret.SecretName,
 					},
-				}
-				envVarSecrets = append(envVarSecrets, dbCDBAdminUserSecret)
-			}
+					Key: pool.DBCDBAdminUserSecret.PasswordKey,
+				},
+			},
 		}
+		envVarSecrets = append(envVarSecrets, dbCDBAdminUserSecret)
 	}
 	return envVarSecrets
 }
@@ -926,11 +1502,18 @@ func (r *RestDataServicesReconciler) ConfigMapDelete(ctx context.Context, req ct
 		if configMap.Name == ords.Name+"-"+globalConfigMapName || configMap.Name == ords.Name+"-init-script" {
 			continue
 		}
+		if _, revision := configMap.Labels[configRevisionLabel]; revision {
+			// Pruned by ConfigRevisionReconcile
+			continue
+		}
 		if _, exists := definedPools[configMap.Name]; !exists {
+			if _, uninstall := configMap.Annotations[uninstallAnnotation]; uninstall {
+				// Deleted by UninstallReconcile once the pool is uninstalled
+				continue
+			}
 			if err := r.Delete(ctx, &configMap); err != nil {
 				return err
 			}
-			RestartPods = ords.Spec.ForceRestart
 			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "ConfigMap %s Deleted", configMap.Name)
 		}
 	}
@@ -938,7 +1521,7 @@ func (r *RestDataServicesReconciler) ConfigMapDelete(ctx context.Context, req ct
 	return nil
 }
 
-func (r *RestDataServicesReconciler) WorkloadDelete(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices, kind string) (err error) {
+func (r *RestDataServicesReconciler) WorkloadDelete(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices, kind string) (migrating bool, err error) {
 	logr := log.FromContext(ctx).WithName("WorkloadDelete")
 
 	// Get Workloads
@@ -948,7 +1531,7 @@ func (r *RestDataServicesReconciler) WorkloadDelete(ctx context.Context, req ctr
 			controllerLabelKey:           controllerLabelVal,
 			"app.kubernetes.io/instance": ords.Name}),
 	); err != nil {
-		return err
+		return false, err
 	}
 
 	statefulSetList := &appsv1.StatefulSetList{}
@@ -957,7 +1540,7 @@ func (r *RestDataServicesReconciler) WorkloadDelete(ctx context.Context, req ctr
 			controllerLabelKey:           controllerLabelVal,
 			"app.kubernetes.io/instance": ords.Name}),
 	); err != nil {
-		return err
+		return false, err
 	}
 
 	daemonSetList := &appsv1.DaemonSetList{}
@@ -966,57 +1549,85 @@ func (r *RestDataServicesReconciler) WorkloadDelete(ctx context.Context, req ctr
 			controllerLabelKey:           controllerLabelVal,
 			"app.kubernetes.io/instance": ords.Name}),
 	); err != nil {
-		return err
+		return false, err
 	}
 
-	switch kind {
-	case "StatefulSet":
-		for _, deleteDaemonSet := range daemonSetList.Items {
-			if err := r.Delete(ctx, &deleteDaemonSet); err != nil {
-				return err
-			}
-			logr.Info("Deleted: " + kind)
-			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Workload %s Deleted", kind)
+	// Workloads of the previous workloadType
+	var previousWorkloads []client.Object
+	if kind != "Deployment" {
+		for i := range deploymentList.Items {
+			previousWorkloads = append(previousWorkloads, &deploymentList.Items[i])
 		}
-		for _, deleteDeployment := range deploymentList.Items {
-			if err := r.Delete(ctx, &deleteDeployment); err != nil {
-				return err
-			}
-			logr.Info("Deleted: " + kind)
-			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Workload %s Deleted", kind)
+	}
+	if kind != "StatefulSet" {
+		for i := range statefulSetList.Items {
+			previousWorkloads = append(previousWorkloads, &statefulSetList.Items[i])
 		}
-	case "DaemonSet":
-		for _, deleteDeployment := range deploymentList.Items {
-			if err := r.Delete(ctx, &deleteDeployment); err != nil {
-				return err
-			}
-			logr.Info("Deleted: " + kind)
-			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Workload %s Deleted", kind)
+	}
+	if kind != "DaemonSet" {
+		for i := range daemonSetList.Items {
+			previousWorkloads = append(previousWorkloads, &daemonSetList.Items[i])
 		}
-		for _, deleteStatefulSet := range statefulSetList.Items {
-			if err := r.Delete(ctx, &deleteStatefulSet); err != nil {
-				return err
-			}
-			logr.Info("Deleted StatefulSet: " + deleteStatefulSet.Name)
-			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Workload %s Deleted", kind)
+	}
+	if len(previousWorkloads) == 0 {
+		return false, nil
+	}
+	previousKind := workloadKind(previousWorkloads[0])
+
+	// The previous Workload keeps serving until the pods of the new Workload are ready
+	workload := workloadObject(kind)
+	if err := r.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, workload); client.IgnoreNotFound(err) != nil {
+		return false, err
+	} else if err != nil || !workloadReady(workload) {
+		message := fmt.Sprintf("Migrating from %s to %s: %s", previousKind, kind, rolloutCondition(workload).Message)
+		if err != nil {
+			message = fmt.Sprintf("Migrating from %s to %s: %s not found", previousKind, kind, kind)
 		}
-	default:
-		for _, deleteStatefulSet := range statefulSetList.Items {
-			if err := r.Delete(ctx, &deleteStatefulSet); err != nil {
-				return err
-			}
-			logr.Info("Deleted: " + kind)
-			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Workload %s Deleted", kind)
+		if !meta.IsStatusConditionTrue(ords.Status.Conditions, typeMigratingORDS) {
+			logr.Info("Migrating: " + previousKind + " to " + kind)
+			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Migrating", "Migrating from %s to %s", previousKind, kind)
 		}
-		for _, deleteDaemonSet := range daemonSetList.Items {
-			if err := r.Delete(ctx, &deleteDaemonSet); err != nil {
-				return err
-			}
-			logr.Info("Deleted: " + kind)
-			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Workload %s Deleted", kind)
+		condition := metav1.Condition{Type: typeMigratingORDS, Status: metav1.ConditionTrue, Reason: "Migrating", Message: message}
+		if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+			return true, err
 		}
+		return true, nil
+	}
+
+	for _, previousWorkload := range previousWorkloads {
+		if err := r.Delete(ctx, previousWorkload); client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+		logr.Info("Deleted: " + workloadKind(previousWorkload))
+		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Workload %s Deleted", workloadKind(previousWorkload))
+	}
+	r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Migrated", "Migrated from %s to %s", previousKind, kind)
+	condition := metav1.Condition{Type: typeMigratingORDS, Status: metav1.ConditionFalse, Reason: "Migrated",
+		Message: fmt.Sprintf("Migrated from %s to %s", previousKind, kind)}
+	if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// workloadKind returns the kind of a Workload
+func workloadKind(workload client.Object) string {
+	switch workload.(type) {
+	case *appsv1.StatefulSet:
+		return "StatefulSet"
+	case *appsv1.DaemonSet:
+		return "DaemonSet"
+	default:
+		return "Deployment"
 	}
-	return nil
+}
+
+// workloadReady returns true when every pod of the Workload is updated and available
+func workloadReady(workload client.Object) bool {
+	if daemonSet, ok := workload.(*appsv1.DaemonSet); ok && daemonSet.Status.DesiredNumberScheduled == 0 {
+		return false
+	}
+	return rolloutCondition(workload).Reason == "RolloutComplete"
 }
 
 /*************************************************
@@ -1029,6 +1640,16 @@ func getLabels(name string) map[string]string {
 	}
 }
 
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func generateSpecHash(spec interface{}) string {
 	byteArray, err := json.Marshal(spec)
 	if err != nil {
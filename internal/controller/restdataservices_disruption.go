@@ -0,0 +1,102 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+/************************************************
+ * PodDisruptionBudget
+ *************************************************/
+// DisruptionBudgetReconcile creates or updates the PodDisruptionBudget of the Workload pods, deleting it once no longer required.
+// While migrating to a DaemonSet it is left as is to protect the pods of the previous Workload.
+func (r *RestDataServicesReconciler) DisruptionBudgetReconcile(ctx context.Context, ords *databasev1.RestDataServices, migrating bool) error {
+	if migrating && ords.Spec.WorkloadType == "DaemonSet" {
+		return nil
+	}
+	definedDisruptionBudget := &policyv1.PodDisruptionBudget{}
+	var desiredDisruptionBudget client.Object
+	var desiredSpecHash string
+	if disruptionBudget := disruptionBudgetSettings(ords); disruptionBudget != nil {
+		desiredDisruptionBudget, desiredSpecHash = disruptionBudgetDefine(ords, disruptionBudget)
+	}
+	return r.ownedReconcile(ctx, ords, "PodDisruptionBudget", definedDisruptionBudget, desiredDisruptionBudget, desiredSpecHash)
+}
+
+func disruptionBudgetDefine(ords *databasev1.RestDataServices, disruptionBudget *databasev1.DisruptionBudgetSettings) (*policyv1.PodDisruptionBudget, string) {
+	selector := selectorDefine(ords)
+	spec := policyv1.PodDisruptionBudgetSpec{
+		Selector:       &selector,
+		MinAvailable:   disruptionBudget.MinAvailable,
+		MaxUnavailable: disruptionBudget.MaxUnavailable,
+	}
+	if spec.MinAvailable == nil && spec.MaxUnavailable == nil {
+		spec.MaxUnavailable = &[]intstr.IntOrString{intstr.FromInt32(1)}[0]
+	}
+
+	objectMeta := objectMetaDefine(ords, ords.Name)
+	specHash := generateSpecHash(spec)
+	objectMeta.Labels[specHashLabel] = specHash
+	return &policyv1.PodDisruptionBudget{ObjectMeta: objectMeta, Spec: spec}, specHash
+}
+
+// disruptionBudgetSettings returns the settings of the PodDisruptionBudget, nil when none is required.
+// DaemonSet pods are not evicted by node drains; a single replica is only protected when specified.
+func disruptionBudgetSettings(ords *databasev1.RestDataServices) *databasev1.DisruptionBudgetSettings {
+	if ords.Spec.WorkloadType == "DaemonSet" {
+		return nil
+	}
+	if ords.Spec.DisruptionBudget != nil {
+		if ords.Spec.DisruptionBudget.Disabled {
+			return nil
+		}
+		return ords.Spec.DisruptionBudget
+	}
+	if ords.Spec.Replicas > 1 || (autoscaled(ords) && ords.Spec.Autoscaling.MaxReplicas > 1) {
+		return &databasev1.DisruptionBudgetSettings{}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Disruption budget", func() {
+	ctx := context.Background()
+	var reconciler *RestDataServicesReconciler
+	var ords *databasev1.RestDataServices
+
+	disruptionBudget := func() (*policyv1.PodDisruptionBudget, error) {
+		definedDisruptionBudget := &policyv1.PodDisruptionBudget{}
+		err := reconciler.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, definedDisruptionBudget)
+		return definedDisruptionBudget, err
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+			Spec:       databasev1.RestDataServicesSpec{Image: "container-registry.oracle.com/database/ords:24.1.0"},
+		}
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+	})
+
+	It("should not protect a single replica by default", func() {
+		Expect(reconciler.DisruptionBudgetReconcile(ctx, ords, false)).To(Succeed())
+		_, err := disruptionBudget()
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should allow a single unavailable pod by default for more than one replica", func() {
+		ords.Spec.Replicas = 3
+		Expect(reconciler.DisruptionBudgetReconcile(ctx, ords, false)).To(Succeed())
+		definedDisruptionBudget, err := disruptionBudget()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metav1.IsControlledBy(definedDisruptionBudget, ords)).To(BeTrue())
+		Expect(definedDisruptionBudget.Spec.Selector.MatchLabels).To(Equal(getLabels(ords.Name)))
+		Expect(definedDisruptionBudget.Spec.MaxUnavailable).To(Equal(&[]intstr.IntOrString{intstr.FromInt32(1)}[0]))
+		Expect(definedDisruptionBudget.Spec.MinAvailable).To(BeNil())
+
+		// Scaled down to a single replica
+		ords.Spec.Replicas = 1
+		Expect(reconciler.DisruptionBudgetReconcile(ctx, ords, false)).To(Succeed())
+		_, err = disruptionBudget()
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should apply the disruption budget settings", func() {
+		minAvailable := intstr.FromString("50%")
+		ords.Spec.DisruptionBudget = &databasev1.DisruptionBudgetSettings{MinAvailable: &minAvailable}
+		Expect(reconciler.DisruptionBudgetReconcile(ctx, ords, false)).To(Succeed())
+		definedDisruptionBudget, err := disruptionBudget()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(definedDisruptionBudget.Spec.MinAvailable).To(Equal(&minAvailable))
+		Expect(definedDisruptionBudget.Spec.MaxUnavailable).To(BeNil())
+
+		ords.Spec.DisruptionBudget.Disabled = true
+		Expect(reconciler.DisruptionBudgetReconcile(ctx, ords, false)).To(Succeed())
+		_, err = disruptionBudget()
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should delete the disruption budget of a DaemonSet", func() {
+		ords.Spec.Replicas = 2
+		Expect(reconciler.DisruptionBudgetReconcile(ctx, ords, false)).To(Succeed())
+		_, err := disruptionBudget()
+		Expect(err).NotTo(HaveOccurred())
+
+		// Kept for the pods of the previous Workload until the migration finishes
+		ords.Spec.WorkloadType = "DaemonSet"
+		Expect(reconciler.DisruptionBudgetReconcile(ctx, ords, true)).To(Succeed())
+		_, err = disruptionBudget()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(reconciler.DisruptionBudgetReconcile(ctx, ords, false)).To(Succeed())
+		_, err = disruptionBudget()
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,84 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"strings"
+)
+
+// imageReference is a parsed container image reference: [registry[:port]/]repository[:tag][@digest]
+type imageReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// parseImageReference parses an image reference; the registry is only set when the first path component
+// is a host, i.e. contains a "." or ":" or is localhost, as the container runtimes resolve it
+func parseImageReference(image string) imageReference {
+	var reference imageReference
+	name, digest, _ := strings.Cut(image, "@")
+	reference.Digest = digest
+
+	// The tag follows the last ":" of the last path component, a ":" before it is a registry port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, reference.Tag = name[:i], name[i+1:]
+	}
+
+	if host, repository, found := strings.Cut(name, "/"); found &&
+		(strings.ContainsAny(host, ".:") || host == "localhost") {
+		reference.Registry, name = host, repository
+	}
+	reference.Repository = name
+	return reference
+}
+
+// Version returns the tag, taken as the ORDS version of the image; empty when only pinned by digest,
+// as the version is not known from the reference, and latest when neither tagged nor pinned
+func (reference imageReference) Version() string {
+	switch {
+	case reference.Tag != "":
+		return reference.Tag
+	case reference.Digest != "":
+		return ""
+	default:
+		return "latest"
+	}
+}
@@ -0,0 +1,75 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Image reference", func() {
+	DescribeTable("parsing",
+		func(image string, expected imageReference) {
+			Expect(parseImageReference(image)).To(Equal(expected))
+		},
+		Entry("registry and tag", "container-registry.oracle.com/database/ords:24.1.0",
+			imageReference{Registry: "container-registry.oracle.com", Repository: "database/ords", Tag: "24.1.0"}),
+		Entry("untagged", "ords", imageReference{Repository: "ords"}),
+		Entry("docker hub namespace", "oracle/ords:24.1.0", imageReference{Repository: "oracle/ords", Tag: "24.1.0"}),
+		Entry("registry port without tag", "registry:5000/ords",
+			imageReference{Registry: "registry:5000", Repository: "ords"}),
+		Entry("registry port and tag", "registry:5000/database/ords:24.1.0",
+			imageReference{Registry: "registry:5000", Repository: "database/ords", Tag: "24.1.0"}),
+		Entry("localhost", "localhost/ords:24.1.0", imageReference{Registry: "localhost", Repository: "ords", Tag: "24.1.0"}),
+		Entry("digest", "registry:5000/ords@sha256:0123abcd",
+			imageReference{Registry: "registry:5000", Repository: "ords", Digest: "sha256:0123abcd"}),
+		Entry("tag and digest", "container-registry.oracle.com/database/ords:24.1.0@sha256:0123abcd",
+			imageReference{Registry: "container-registry.oracle.com", Repository: "database/ords", Tag: "24.1.0", Digest: "sha256:0123abcd"}),
+	)
+
+	DescribeTable("version",
+		func(image string, expected string) {
+			Expect(parseImageReference(image).Version()).To(Equal(expected))
+		},
+		Entry("tagged", "container-registry.oracle.com/database/ords:24.1.0", "24.1.0"),
+		Entry("untagged", "registry:5000/ords", "latest"),
+		Entry("tag and digest", "ords:24.1.0@sha256:0123abcd", "24.1.0"),
+		Entry("digest", "ords@sha256:0123abcd", ""),
+	)
+})
@@ -0,0 +1,250 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+/************************************************
+ * Install/Upgrade
+ *************************************************/
+// InstallReconcile runs an install Job for each pool with a db.adminUser.secret; the Job installs/upgrades
+// ORDS and APEX, or creates the ADB runtime user, once per image.  It returns true when every pool has been
+// installed with the current image and the Workload can be rolled out.
+func (r *RestDataServicesReconciler) InstallReconcile(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices) (installed bool, err error) {
+	logr := log.FromContext(ctx).WithName("InstallReconcile")
+
+	definedJobs := make(map[string]bool)
+	var running, failed, completed, pending []string
+	var manual bool
+	results := make(map[string]initResult)
+	toVersion, approveVersion, err := r.upgradeVersion(ctx, ords)
+	if err != nil {
+		return false, err
+	}
+	for _, pool := range ords.Spec.PoolSettings {
+		if !installRequired(pool) {
+			continue
+		}
+		poolName := strings.ToLower(pool.PoolName)
+		definedJobs[ords.Name+"-install-"+poolName] = true
+
+		// Wait for the upgrade to the image version to be approved; an unknown installed version, i.e. before the
+		// first install Job reported it, could be any version and is approved likewise
+		if pool.UpgradePolicy == databasev1.UpgradePolicyManual {
+			manual = true
+			fromVersion := installedVersion(ords, poolName)
+			if !versionMatches(fromVersion, toVersion) && ords.Annotations[approveUpgradeAnnotation] != approveVersion {
+				if fromVersion == "" {
+					fromVersion = "unknown"
+				}
+				pending = append(pending, fmt.Sprintf("%s from %s", poolName, fromVersion))
+				continue
+			}
+		}
+
+		job, err := r.PoolJobReconcile(ctx, ords, pool, "install")
+		if err != nil {
+			return false, err
+		}
+		state := jobState(job)
+		if state != "" {
+			jobResults, err := r.jobResults(ctx, job)
+			if err != nil {
+				return false, err
+			}
+			for _, result := range jobResults {
+				results[result.Pool] = result
+			}
+		}
+		switch state {
+		case batchv1.JobComplete:
+			completed = append(completed, poolName)
+		case batchv1.JobFailed:
+			logr.Info("Failed: " + job.Name)
+			message := fmt.Sprintf("Job %s Failed", job.Name)
+			if result, ok := results[poolName]; ok && result.Error != "" {
+				message += ": " + result.Error
+			}
+			r.Recorder.Event(ords, corev1.EventTypeWarning, "InstallFailed", message)
+			failed = append(failed, poolName)
+		default:
+			running = append(running, poolName)
+		}
+	}
+
+	// Delete the install Jobs of pools no longer installed
+	jobList := &batchv1.JobList{}
+	if err := r.List(ctx, jobList, client.InNamespace(req.Namespace),
+		client.MatchingLabels(map[string]string{
+			controllerLabelKey:           controllerLabelVal,
+			"app.kubernetes.io/instance": ords.Name}),
+	); err != nil {
+		return false, err
+	}
+	for _, job := range jobList.Items {
+		if !strings.HasPrefix(job.Name, ords.Name+"-install-") || definedJobs[job.Name] {
+			continue
+		}
+		if err := r.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+		logr.Info("Deleted: " + job.Name)
+		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Job %s Deleted", job.Name)
+	}
+
+	if err := r.PoolStatusReconcile(ctx, req, ords, results); err != nil {
+		return false, err
+	}
+
+	if manual {
+		condition := metav1.Condition{Type: typePendingUpgradeORDS, Status: metav1.ConditionFalse, Reason: "Approved",
+			Message: "No upgrade pending approval"}
+		if len(pending) > 0 {
+			logr.Info("Pending upgrade approval: " + strings.Join(pending, ", "))
+			condition = metav1.Condition{Type: typePendingUpgradeORDS, Status: metav1.ConditionTrue, Reason: "AwaitingApproval",
+				Message: fmt.Sprintf("Upgrade of pools %s to %s is pending; approve by setting annotation %s=%s",
+					strings.Join(pending, ", "), approveVersion, approveUpgradeAnnotation, approveVersion)}
+		}
+		if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+			return false, err
+		}
+	}
+
+	var condition metav1.Condition
+	switch {
+	case len(failed) > 0:
+		condition = metav1.Condition{Type: typeInstalledORDS, Status: metav1.ConditionFalse, Reason: "Failed",
+			Message: fmt.Sprintf("Failed to install pools %s with %s; delete the Job to retry",
+				strings.Join(failed, ", "), ords.Spec.Image)}
+	case len(running) > 0:
+		condition = metav1.Condition{Type: typeInstalledORDS, Status: metav1.ConditionFalse, Reason: "Installing",
+			Message: fmt.Sprintf("Installing pools %s with %s", strings.Join(running, ", "), ords.Spec.Image)}
+	case len(completed) > 0:
+		condition = metav1.Condition{Type: typeInstalledORDS, Status: metav1.ConditionTrue, Reason: "Installed",
+			Message: fmt.Sprintf("Installed pools %s with %s", strings.Join(completed, ", "), ords.Spec.Image)}
+	default:
+		return len(pending) == 0, nil
+	}
+	if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+		return false, err
+	}
+	return len(running) == 0 && len(failed) == 0 && len(pending) == 0, nil
+}
+
+// installedVersion returns the ORDS schema version installed in the pool's database, as reported in
+// status.pools; empty when it is unknown
+func installedVersion(ords *databasev1.RestDataServices, poolName string) string {
+	for _, poolStatus := range ords.Status.Pools {
+		if strings.ToLower(poolStatus.PoolName) == poolName {
+			return poolStatus.ORDSVersion
+		}
+	}
+	return ""
+}
+
+// versionMatches returns true when a schema version, i.e. 24.1.0.r1234, is of the image version 24.1.0
+func versionMatches(schemaVersion string, imageVersion string) bool {
+	if schemaVersion == "" {
+		return false
+	}
+	return schemaVersion == imageVersion || strings.HasPrefix(schemaVersion, imageVersion+".")
+}
+
+// installRequired returns true when the init script has work to do in the pool's database
+func installRequired(pool *databasev1.PoolSettings) bool {
+	return pool.DBAdminUserSecret.SecretName != "" && pool.UpgradePolicy != databasev1.UpgradePolicyNever
+}
+
+// heldImage returns the resource with the image of its running Workload, so that the other changes are rolled out
+// while the pools are installed with the new image; nil when the Workload has not been created yet
+func (r *RestDataServicesReconciler) heldImage(ctx context.Context, ords *databasev1.RestDataServices) (*databasev1.RestDataServices, error) {
+	image, err := r.workloadImage(ctx, ords)
+	if err != nil || image == "" {
+		return nil, err
+	}
+	held := ords.DeepCopy()
+	held.Spec.Image = image
+	return held, nil
+}
+
+// workloadImage returns the image of the Workload; empty when it has not been created yet
+func (r *RestDataServicesReconciler) workloadImage(ctx context.Context, ords *databasev1.RestDataServices) (string, error) {
+	definedWorkload := workloadObject(ords.Spec.WorkloadType)
+	if err := r.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, definedWorkload); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	for _, container := range workloadPodTemplate(definedWorkload).Spec.Containers {
+		if container.Name == ords.Name {
+			return container.Image, nil
+		}
+	}
+	return "", nil
+}
+
+// upgradeVersion returns the ORDS version of the image and the version approving the upgrade to it.  The version
+// of an image only pinned by digest is that reported by the running pods once the Workload runs the image; until
+// then it is unknown and the upgrade is approved with the digest.
+func (r *RestDataServicesReconciler) upgradeVersion(ctx context.Context, ords *databasev1.RestDataServices) (toVersion string, approveVersion string, err error) {
+	reference := parseImageReference(ords.Spec.Image)
+	if toVersion = reference.Version(); toVersion != "" {
+		return toVersion, toVersion, nil
+	}
+	image, err := r.workloadImage(ctx, ords)
+	if err != nil {
+		return "", "", err
+	}
+	if image == ords.Spec.Image {
+		toVersion = ords.Status.ORDSVersion
+	}
+	return toVersion, reference.Digest, nil
+}
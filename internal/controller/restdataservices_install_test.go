@@ -0,0 +1,331 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Pool install", func() {
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "ords", Namespace: "default"}}
+	jobKey := types.NamespacedName{Name: "ords-install-default", Namespace: "default"}
+	var reconciler *RestDataServicesReconciler
+	var ords *databasev1.RestDataServices
+
+	installJob := func() *batchv1.Job {
+		job := &batchv1.Job{}
+		Expect(reconciler.Get(ctx, jobKey, job)).To(Succeed())
+		return job
+	}
+
+	finishJob := func(conditionType batchv1.JobConditionType) {
+		job := installJob()
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		Expect(reconciler.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	finishPod := func(message string) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: jobKey.Name + "-abcde", Namespace: jobKey.Namespace,
+				Labels: map[string]string{"job-name": jobKey.Name}},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "install",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Message: message, FinishedAt: metav1.Now()}},
+			}}},
+		}
+		Expect(reconciler.Create(ctx, pod)).To(Succeed())
+	}
+
+	install := func() bool {
+		installed, err := reconciler.InstallReconcile(ctx, req, ords)
+		Expect(err).NotTo(HaveOccurred())
+		return installed
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+			Spec: databasev1.RestDataServicesSpec{
+				Image: "container-registry.oracle.com/database/ords:24.1.0",
+				PoolSettings: []*databasev1.PoolSettings{{
+					PoolName:          "default",
+					AutoUpgradeORDS:   true,
+					DBAdminUser:       "SYS",
+					DBSecret:          databasev1.PasswordSecret{SecretName: "db-auth"},
+					DBAdminUserSecret: databasev1.PasswordSecret{SecretName: "db-admin-auth"},
+				}},
+			},
+		}
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+	})
+
+	It("should not run a Job for pools without an admin user", func() {
+		ords.Spec.PoolSettings[0].DBAdminUserSecret = databasev1.PasswordSecret{}
+		Expect(install()).To(BeTrue())
+		Expect(apierrors.IsNotFound(reconciler.Get(ctx, jobKey, &batchv1.Job{}))).To(BeTrue())
+	})
+
+	It("should hold the rollout until the install Job completes", func() {
+		Expect(install()).To(BeFalse())
+		Expect(installJob().Spec.Template.Spec.Containers[0].Command).To(ContainElement(ContainSubstring("install default")))
+		Expect(installJob().Spec.BackoffLimit).To(HaveValue(BeZero()))
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typeInstalledORDS).Reason).To(Equal("Installing"))
+
+		finishJob(batchv1.JobComplete)
+		Expect(install()).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(ords.Status.Conditions, typeInstalledORDS)).To(BeTrue())
+	})
+
+	It("should hold the rollout when the install Job fails", func() {
+		Expect(install()).To(BeFalse())
+		finishJob(batchv1.JobFailed)
+		Expect(install()).To(BeFalse())
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typeInstalledORDS).Reason).To(Equal("Failed"))
+	})
+
+	It("should reconcile everything but the Workload while installing", func() {
+		ords.Spec.Service.Type = corev1.ServiceTypeNodePort
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		service := &corev1.Service{}
+		Expect(reconciler.Get(ctx, req.NamespacedName, service)).To(Succeed())
+		Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeNodePort))
+		Expect(apierrors.IsNotFound(reconciler.Get(ctx, req.NamespacedName, &appsv1.Deployment{}))).To(BeTrue())
+	})
+
+	It("should roll out the other changes with the running image while installing", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		finishJob(batchv1.JobComplete)
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(reconciler.Get(ctx, req.NamespacedName, ords)).To(Succeed())
+		ords.Spec.Image = "container-registry.oracle.com/database/ords:24.2.0"
+		ords.Spec.Replicas = 3
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		deployment := &appsv1.Deployment{}
+		Expect(reconciler.Get(ctx, req.NamespacedName, deployment)).To(Succeed())
+		Expect(deployment.Spec.Replicas).To(HaveValue(Equal(int32(3))))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(HaveSuffix("24.1.0"))
+		Expect(deployment.Spec.Template.Spec.InitContainers[0].Image).To(HaveSuffix("24.1.0"))
+	})
+
+	It("should not install again when the other settings change", func() {
+		Expect(install()).To(BeFalse())
+		finishJob(batchv1.JobComplete)
+		Expect(install()).To(BeTrue())
+
+		ords.Spec.ImagePullPolicy = corev1.PullAlways
+		ords.Spec.ImagePullSecrets = databasev1.ImagePullSecrets{{Name: "registry-auth"}}
+		Expect(install()).To(BeTrue())
+		Expect(jobState(installJob())).To(Equal(batchv1.JobComplete))
+	})
+
+	It("should install again when the image changes", func() {
+		Expect(install()).To(BeFalse())
+		finishJob(batchv1.JobComplete)
+		Expect(install()).To(BeTrue())
+
+		ords.Spec.Image = "container-registry.oracle.com/database/ords:24.2.0"
+		Expect(install()).To(BeFalse())
+		Expect(apierrors.IsNotFound(reconciler.Get(ctx, jobKey, &batchv1.Job{}))).To(BeTrue())
+
+		ords.Spec.Image = "container-registry.oracle.com/database/ords:24.2.0"
+		Expect(install()).To(BeFalse())
+		Expect(installJob().Spec.Template.Spec.Containers[0].Image).To(HaveSuffix("24.2.0"))
+	})
+
+	It("should not run a Job for pools that are never upgraded", func() {
+		ords.Spec.PoolSettings[0].UpgradePolicy = databasev1.UpgradePolicyNever
+		Expect(install()).To(BeTrue())
+		Expect(apierrors.IsNotFound(reconciler.Get(ctx, jobKey, &batchv1.Job{}))).To(BeTrue())
+	})
+
+	It("should wait for the approval of a Manual pool whose installed version is unknown", func() {
+		ords.Spec.PoolSettings[0].UpgradePolicy = databasev1.UpgradePolicyManual
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		Expect(install()).To(BeFalse())
+		Expect(ords.Status.Pools).To(HaveEach(HaveField("ORDSVersion", BeEmpty())))
+		Expect(apierrors.IsNotFound(reconciler.Get(ctx, jobKey, &batchv1.Job{}))).To(BeTrue())
+		condition := meta.FindStatusCondition(ords.Status.Conditions, typePendingUpgradeORDS)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring("default from unknown to 24.1.0"))
+
+		ords.Annotations = map[string]string{approveUpgradeAnnotation: "24.1.0"}
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		Expect(install()).To(BeFalse())
+		Expect(installJob().Spec.Template.Spec.Containers[0].Image).To(HaveSuffix("24.1.0"))
+		Expect(meta.IsStatusConditionFalse(ords.Status.Conditions, typePendingUpgradeORDS)).To(BeTrue())
+	})
+
+	It("should wait for the approval of the exact version to upgrade", func() {
+		ords.Spec.PoolSettings[0].UpgradePolicy = databasev1.UpgradePolicyManual
+		ords.Annotations = map[string]string{approveUpgradeAnnotation: "24.1.0"}
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		Expect(install()).To(BeFalse())
+		finishPod(`{"ordsVersion":"24.1.0.r1234","pools":[{"pool":"default","exitCode":0,"result":"Succeeded","action":"ordsInstall",` +
+			`"dbType":"Oracle","ordsVersion":"24.1.0.r1234","apexVersion":"","connected":true,"error":""}]}`)
+		finishJob(batchv1.JobComplete)
+		Expect(install()).To(BeTrue())
+
+		// The installed version is taken from the pool status, not the install Job
+		Expect(reconciler.Delete(ctx, installJob())).To(Succeed())
+		ords.Spec.Image = "container-registry.oracle.com/database/ords:24.2.0"
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		Expect(install()).To(BeFalse())
+		Expect(apierrors.IsNotFound(reconciler.Get(ctx, jobKey, &batchv1.Job{}))).To(BeTrue())
+		condition := meta.FindStatusCondition(ords.Status.Conditions, typePendingUpgradeORDS)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring("default from 24.1.0.r1234 to 24.2.0"))
+
+		// The approval of the previous version does not approve the upgrade
+		Expect(ords.Annotations).To(HaveKeyWithValue(approveUpgradeAnnotation, "24.1.0"))
+
+		ords.Annotations = map[string]string{approveUpgradeAnnotation: "24.2.0"}
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		Expect(install()).To(BeFalse())
+		Expect(meta.IsStatusConditionFalse(ords.Status.Conditions, typePendingUpgradeORDS)).To(BeTrue())
+		Expect(installJob().Spec.Template.Spec.Containers[0].Image).To(HaveSuffix("24.2.0"))
+	})
+
+	It("should approve the upgrade to an image pinned by digest with the digest", func() {
+		ords.Spec.Image = "container-registry.oracle.com/database/ords@sha256:0123abcd"
+		ords.Spec.PoolSettings[0].UpgradePolicy = databasev1.UpgradePolicyManual
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		Expect(install()).To(BeFalse())
+		Expect(apierrors.IsNotFound(reconciler.Get(ctx, jobKey, &batchv1.Job{}))).To(BeTrue())
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typePendingUpgradeORDS).Message).To(
+			ContainSubstring("default from unknown to sha256:0123abcd"))
+
+		ords.Annotations = map[string]string{approveUpgradeAnnotation: "sha256:0123abcd"}
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		Expect(install()).To(BeFalse())
+		finishPod(`{"ordsVersion":"24.1.0.r1234","pools":[{"pool":"default","exitCode":0,"result":"Succeeded","action":"ordsInstall",` +
+			`"dbType":"Oracle","ordsVersion":"24.1.0.r1234","apexVersion":"","connected":true,"error":""}]}`)
+		finishJob(batchv1.JobComplete)
+		Expect(install()).To(BeTrue())
+
+		// Once the Workload runs the image, its version is that of the running pods
+		Expect(reconciler.Create(ctx, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "ords", Image: ords.Spec.Image}}}}},
+		})).To(Succeed())
+		ords.Status.ORDSVersion = "24.1.0.r1234"
+		Expect(reconciler.Status().Update(ctx, ords)).To(Succeed())
+		ords.Annotations = nil
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		Expect(install()).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(ords.Status.Conditions, typePendingUpgradeORDS)).To(BeTrue())
+	})
+
+	It("should delete the install Job of a removed pool", func() {
+		Expect(install()).To(BeFalse())
+		ords.Spec.PoolSettings = nil
+		Expect(install()).To(BeTrue())
+		Expect(apierrors.IsNotFound(reconciler.Get(ctx, jobKey, &batchv1.Job{}))).To(BeTrue())
+	})
+
+	It("should report the pool status from the install Job results", func() {
+		Expect(install()).To(BeFalse())
+		Expect(ords.Status.Pools).To(Equal([]databasev1.PoolStatus{{PoolName: "default"}}))
+
+		finishPod(`{"ordsVersion":"24.1.0.r1234","pools":[{"pool":"default","exitCode":0,"result":"Succeeded","action":"ordsInstall",` +
+			`"dbType":"Oracle","ordsVersion":"24.1.0.r1234","apexVersion":"23.2.0","connected":true,"error":""}]}`)
+		finishJob(batchv1.JobComplete)
+		Expect(install()).To(BeTrue())
+		Expect(ords.Status.Pools).To(HaveLen(1))
+		poolStatus := ords.Status.Pools[0]
+		Expect(poolStatus.DBType).To(Equal("Oracle"))
+		Expect(poolStatus.ORDSVersion).To(Equal("24.1.0.r1234"))
+		Expect(poolStatus.APEXVersion).To(Equal("23.2.0"))
+		Expect(poolStatus.LastAction).To(Equal("ordsInstall"))
+		Expect(poolStatus.LastActionResult).To(Equal("Succeeded"))
+		Expect(poolStatus.LastConnectionCheck).NotTo(BeNil())
+		Expect(poolStatus.ConnectionError).To(BeEmpty())
+	})
+
+	It("should report the connection error of a failed install Job", func() {
+		Expect(install()).To(BeFalse())
+		finishPod(`{"ordsVersion":"24.1.0.r1234","pools":[{"pool":"default","exitCode":1,"result":"Failed","action":"",` +
+			`"dbType":"","ordsVersion":"","apexVersion":"","connected":false,"error":"ORA-12541: TNS:no listener"}]}`)
+		finishJob(batchv1.JobFailed)
+		Expect(install()).To(BeFalse())
+		Expect(ords.Status.Pools[0].LastActionResult).To(Equal("Failed"))
+		Expect(ords.Status.Pools[0].ConnectionError).To(Equal("ORA-12541: TNS:no listener"))
+		Eventually(reconciler.Recorder.(*record.FakeRecorder).Events).Should(Receive(ContainSubstring("ORA-12541")))
+	})
+
+	It("should report a failed install Job whose termination message is not a result", func() {
+		Expect(install()).To(BeFalse())
+		finishPod("Killed")
+		finishJob(batchv1.JobFailed)
+		Expect(install()).To(BeFalse())
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typeInstalledORDS).Reason).To(Equal("Failed"))
+		Eventually(reconciler.Recorder.(*record.FakeRecorder).Events).Should(Receive(ContainSubstring("InstallFailed Job ords-install-default Failed")))
+	})
+
+	It("should ignore termination messages that are not results", func() {
+		_, err := parseInitResults("Killed", metav1.Now())
+		Expect(err).To(HaveOccurred())
+	})
+})
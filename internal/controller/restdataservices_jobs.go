@@ -0,0 +1,173 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+/************************************************
+ * Pool Jobs
+ *************************************************/
+// PoolJobReconcile creates the Job running the init script action (install/uninstall) for a pool.
+// A finished Job is replaced when the image has changed; a running Job is left to finish.
+func (r *RestDataServicesReconciler) PoolJobReconcile(ctx context.Context, ords *databasev1.RestDataServices, pool *databasev1.PoolSettings, action string) (*batchv1.Job, error) {
+	logr := log.FromContext(ctx).WithName("PoolJobReconcile")
+	desiredJob, desiredSpecHash := poolJobDefine(ords, pool, action)
+
+	definedJob := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: desiredJob.Name, Namespace: ords.Namespace}, definedJob); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err := ctrl.SetControllerReference(ords, desiredJob, r.Scheme); err != nil {
+			return nil, err
+		}
+		if err := r.Create(ctx, desiredJob); err != nil {
+			return nil, err
+		}
+		logr.Info("Created: " + desiredJob.Name)
+		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Create", "Job %s Created", desiredJob.Name)
+		return desiredJob, nil
+	}
+
+	if definedJob.GetLabels()[specHashLabel] != desiredSpecHash && jobState(definedJob) != "" {
+		// Job specs are immutable; the replacement is created once the Job is deleted
+		if err := r.Delete(ctx, definedJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		logr.Info("Deleted: " + definedJob.Name)
+		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Job %s Deleted", definedJob.Name)
+		return desiredJob, nil
+	}
+	return definedJob, nil
+}
+
+// jobState returns the finished condition of a Job; empty while it is running
+func jobState(job *batchv1.Job) batchv1.JobConditionType {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return condition.Type
+		}
+	}
+	return ""
+}
+
+// poolJobDefine returns the Job running the init script action for a pool and its spec hash, that of the image.
+// The pod is configured as the init container for the pool only and is not selected by the Service.
+func poolJobDefine(ords *databasev1.RestDataServices, pool *databasev1.PoolSettings, action string) (*batchv1.Job, string) {
+	poolName := strings.ToLower(pool.PoolName)
+	objectMeta := objectMetaDefine(ords, ords.Name+"-"+action+"-"+poolName)
+
+	volumes := []corev1.Volume{
+		volumeBuild(ords.Name+"-"+"init-script", "ConfigMap", 0770),
+		volumeBuild("sa-wallet-global", "EmptyDir"),
+		volumeBuild(ords.Name+"-"+globalConfigMapName, "ConfigMap"),
+	}
+	volumeMounts := []corev1.VolumeMount{
+		volumeMountBuild(ords.Name+"-"+"init-script", ordsSABase+"/bin", true),
+		volumeMountBuild("sa-wallet-global", ordsSABase+"/config/global/wallet/", false),
+		volumeMountBuild(ords.Name+"-"+globalConfigMapName, ordsSABase+"/config/global/", true),
+	}
+	poolVolumes, poolVolumeMounts := poolVolumesDefine(ords, pool)
+	volumes = append(volumes, poolVolumes...)
+	volumeMounts = append(volumeMounts, poolVolumeMounts...)
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "ORDS_CONFIG",
+			Value: ordsSABase + "/config",
+		},
+		{
+			Name:  "JAVA_TOOL_OPTIONS",
+			Value: "-Doracle.ml.version_check=false",
+		},
+		{
+			Name:  "TNS_ADMIN",
+			Value: ordsSABase + "/config/databases/" + poolName + "/network/admin/",
+		},
+	}
+	env = append(env, poolEnvDefine(pool)...)
+
+	spec := batchv1.JobSpec{
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				RestartPolicy:    corev1.RestartPolicyNever,
+				Volumes:          volumes,
+				ImagePullSecrets: ords.Spec.ImagePullSecrets,
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot: &[]bool{true}[0],
+					FSGroup:      &[]int64{54321}[0],
+					SeccompProfile: &corev1.SeccompProfile{
+						Type: corev1.SeccompProfileTypeRuntimeDefault,
+					},
+				},
+				Containers: []corev1.Container{{
+					Image:           ords.Spec.Image,
+					Name:            action,
+					ImagePullPolicy: ords.Spec.ImagePullPolicy,
+					SecurityContext: securityContextDefine(),
+					Command:         []string{"sh", "-c", ordsSABase + "/bin/init_script.sh " + action + " " + poolName},
+					Env:             env,
+					VolumeMounts:    volumeMounts,
+				}},
+			},
+		},
+	}
+	// The action is run once per image; changes to the other settings are not a reason to run it again
+	specHash := generateSpecHash(ords.Spec.Image)
+	objectMeta.Labels[specHashLabel] = specHash
+
+	podSchedulingDefine(ords, &spec.Template.Spec)
+	// A failed action is not retried against the database; the Job is deleted to retry
+	spec.BackoffLimit = &[]int32{0}[0]
+	return &batchv1.Job{ObjectMeta: objectMeta, Spec: spec}, specHash
+}
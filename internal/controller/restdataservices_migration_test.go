@@ -0,0 +1,131 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Workload migration", func() {
+	ctx := context.Background()
+	var reconciler *RestDataServicesReconciler
+	var recorder *record.FakeRecorder
+	var ords *databasev1.RestDataServices
+	var req ctrl.Request
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+			Spec:       databasev1.RestDataServicesSpec{Image: "container-registry.oracle.com/database/ords:24.1.0"},
+		}
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+		recorder = reconciler.Recorder.(*record.FakeRecorder)
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}}
+
+		// The Deployment of the previous workloadType
+		_, err := reconciler.WorkloadReconcile(ctx, req, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	deploymentExists := func() bool {
+		err := reconciler.Get(ctx, req.NamespacedName, &appsv1.Deployment{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	It("should keep the previous Workload until the pods of the new Workload are ready", func() {
+		_, err := reconciler.WorkloadReconcile(ctx, req, ords, "StatefulSet", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		migrating, err := reconciler.WorkloadDelete(ctx, req, ords, "StatefulSet")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(migrating).To(BeTrue())
+		Expect(deploymentExists()).To(BeTrue())
+		condition := meta.FindStatusCondition(ords.Status.Conditions, typeMigratingORDS)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring("Migrating from Deployment to StatefulSet"))
+		Eventually(recorder.Events).Should(Receive(ContainSubstring("Migrating from Deployment to StatefulSet")))
+
+		statefulSet := &appsv1.StatefulSet{}
+		Expect(reconciler.Get(ctx, req.NamespacedName, statefulSet)).To(Succeed())
+		statefulSet.Status = appsv1.StatefulSetStatus{Replicas: 1, ReadyReplicas: 1, AvailableReplicas: 1, UpdatedReplicas: 1}
+		Expect(reconciler.Status().Update(ctx, statefulSet)).To(Succeed())
+
+		migrating, err = reconciler.WorkloadDelete(ctx, req, ords, "StatefulSet")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(migrating).To(BeFalse())
+		Expect(deploymentExists()).To(BeFalse())
+		condition = meta.FindStatusCondition(ords.Status.Conditions, typeMigratingORDS)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("Migrated"))
+		Eventually(recorder.Events).Should(Receive(ContainSubstring("Migrated from Deployment to StatefulSet")))
+	})
+
+	It("should not migrate when the Workload type is unchanged", func() {
+		migrating, err := reconciler.WorkloadDelete(ctx, req, ords, "Deployment")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(migrating).To(BeFalse())
+		Expect(deploymentExists()).To(BeTrue())
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typeMigratingORDS)).To(BeNil())
+	})
+
+	It("should not delete the previous Workload for a DaemonSet without scheduled pods", func() {
+		_, err := reconciler.WorkloadReconcile(ctx, req, ords, "DaemonSet", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		migrating, err := reconciler.WorkloadDelete(ctx, req, ords, "DaemonSet")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(migrating).To(BeTrue())
+		Expect(deploymentExists()).To(BeTrue())
+	})
+})
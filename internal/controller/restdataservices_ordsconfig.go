@@ -40,98 +40,57 @@ package controller
 
 import (
 	"context"
+	_ "embed"
+	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
-	"time"
 
 	databasev1 "example.com/oracle-ords-operator/api/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func (r *RestDataServicesReconciler) ConfigMapDefine(ctx context.Context, ords *databasev1.RestDataServices, configMapName string, poolIndex int) *corev1.ConfigMap {
+// ordsInitScript is run by the init container unless replaced by spec.initScript
+//
+//go:embed ords_init.sh
+var ordsInitScript string
+
+func (r *RestDataServicesReconciler) ConfigMapDefine(ctx context.Context, ords *databasev1.RestDataServices, configMapName string, poolIndex int) (*corev1.ConfigMap, error) {
 	var defData map[string]string
+	var defAnnotations map[string]string
 	if configMapName == ords.Name+"-init-script" {
-		// Read the file from controller's filesystem
-		filePath := "/ords_init.sh"
-		scriptData, err := os.ReadFile(filePath)
+		scriptData, err := r.initScript(ctx, ords)
 		if err != nil {
-			return nil
+			return nil, err
 		}
 		defData = map[string]string{
-			"init_script.sh": string(scriptData)}
+			"init_script.sh": scriptData}
 	} else if configMapName == ords.Name+"-"+globalConfigMapName {
 		// GlobalConfigMap
-		var defStandaloneAccessLog string
+		props := ordsProperties{}
+		if err := props.setTyped(ords.Spec.GlobalSettings); err != nil {
+			return nil, err
+		}
+		props.set("security.httpsHeaderCheck", httpsHeaderCheck(ords))
+		props.set("standalone.doc.root", ordsSABase+"/config/global/doc_root/")
+		// Dynamic
 		if ords.Spec.GlobalSettings.EnableStandaloneAccessLog {
-			defStandaloneAccessLog = `  <entry key="standalone.access.log">` + ordsSABase + `/log/global</entry>` + "\n"
+			props.set("standalone.access.log", ordsSABase+"/log/global")
 		}
-		var defMongoAccessLog string
 		if ords.Spec.GlobalSettings.EnableMongoAccessLog {
-			defMongoAccessLog = `  <entry key="mongo.access.log">` + ordsSABase + `/log/global</entry>` + "\n"
+			props.set("mongo.access.log", ordsSABase+"/log/global")
 		}
-		var defCert string
 		if ords.Spec.GlobalSettings.CertSecret != nil {
-			defCert = `  <entry key="standalone.https.cert">` + ordsSABase + `/config/certficate/` + ords.Spec.GlobalSettings.CertSecret.Certificate + `</entry>` + "\n" +
-				`  <entry key="standalone.https.cert.key">` + ordsSABase + `/config/certficate/` + ords.Spec.GlobalSettings.CertSecret.CertificateKey + `</entry>` + "\n"
+			props.set("standalone.https.cert", ordsSABase+"/config/certficate/"+ords.Spec.GlobalSettings.CertSecret.Certificate)
+			props.set("standalone.https.cert.key", ordsSABase+"/config/certficate/"+ords.Spec.GlobalSettings.CertSecret.CertificateKey)
 		}
+		// Settings without a field, such as standalone.static.context.path, are set from additionalSettings
+		props.setAdditional(ords.Spec.GlobalSettings.AdditionalSettings)
+
 		defData = map[string]string{
-			"settings.xml": fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
-				`<!DOCTYPE properties SYSTEM "http://java.sun.com/dtd/properties.dtd">` + "\n" +
-				`<properties>` + "\n" +
-				conditionalEntry("cache.metadata.graphql.expireAfterAccess", ords.Spec.GlobalSettings.CacheMetadataGraphQLExpireAfterAccess) +
-				conditionalEntry("cache.metadata.jwks.enabled", ords.Spec.GlobalSettings.CacheMetadataJWKSEnabled) +
-				conditionalEntry("cache.metadata.jwks.initialCapacity", ords.Spec.GlobalSettings.CacheMetadataJWKSInitialCapacity) +
-				conditionalEntry("cache.metadata.jwks.maximumSize", ords.Spec.GlobalSettings.CacheMetadataJWKSMaximumSize) +
-				conditionalEntry("cache.metadata.jwks.expireAfterAccess", ords.Spec.GlobalSettings.CacheMetadataJWKSExpireAfterAccess) +
-				conditionalEntry("cache.metadata.jwks.expireAfterWrite", ords.Spec.GlobalSettings.CacheMetadataJWKSExpireAfterWrite) +
-				conditionalEntry("database.api.management.services.disabled", ords.Spec.GlobalSettings.DatabaseAPIManagementServicesDisabled) +
-				conditionalEntry("db.invalidPoolTimeout", ords.Spec.GlobalSettings.DBInvalidPoolTimeout) +
-				conditionalEntry("feature.graphql.max.nesting.depth", ords.Spec.GlobalSettings.FeatureGraphQLMaxNestingDepth) +
-				conditionalEntry("request.traceHeaderName", ords.Spec.GlobalSettings.RequestTraceHeaderName) +
-				conditionalEntry("security.credentials.attempts", ords.Spec.GlobalSettings.SecurityCredentialsAttempts) +
-				conditionalEntry("security.credentials.lock.time", ords.Spec.GlobalSettings.SecurityCredentialsLockTime) +
-				conditionalEntry("standalone.context.path", ords.Spec.GlobalSettings.StandaloneContextPath) +
-				conditionalEntry("standalone.http.port", ords.Spec.GlobalSettings.StandaloneHTTPPort) +
-				conditionalEntry("standalone.https.host", ords.Spec.GlobalSettings.StandaloneHTTPSHost) +
-				conditionalEntry("standalone.https.port", ords.Spec.GlobalSettings.StandaloneHTTPSPort) +
-				conditionalEntry("standalone.stop.timeout", ords.Spec.GlobalSettings.StandaloneStopTimeout) +
-				conditionalEntry("cache.metadata.timeout", ords.Spec.GlobalSettings.CacheMetadataTimeout) +
-				conditionalEntry("cache.metadata.enabled", ords.Spec.GlobalSettings.CacheMetadataEnabled) +
-				conditionalEntry("database.api.enabled", ords.Spec.GlobalSettings.DatabaseAPIEnabled) +
-				conditionalEntry("debug.printDebugToScreen", ords.Spec.GlobalSettings.DebugPrintDebugToScreen) +
-				conditionalEntry("error.responseFormat", ords.Spec.GlobalSettings.ErrorResponseFormat) +
-				conditionalEntry("icap.port", ords.Spec.GlobalSettings.ICAPPort) +
-				conditionalEntry("icap.secure.port", ords.Spec.GlobalSettings.ICAPSecurePort) +
-				conditionalEntry("icap.server", ords.Spec.GlobalSettings.ICAPServer) +
-				conditionalEntry("log.procedure", ords.Spec.GlobalSettings.LogProcedure) +
-				conditionalEntry("mongo.enabled", ords.Spec.GlobalSettings.MongoEnabled) +
-				conditionalEntry("mongo.port", ords.Spec.GlobalSettings.MongoPort) +
-				conditionalEntry("mongo.idle.timeout", ords.Spec.GlobalSettings.MongoIdleTimeout) +
-				conditionalEntry("mongo.op.timeout", ords.Spec.GlobalSettings.MongoOpTimeout) +
-				conditionalEntry("security.disableDefaultExclusionList", ords.Spec.GlobalSettings.SecurityDisableDefaultExclusionList) +
-				conditionalEntry("security.exclusionList", ords.Spec.GlobalSettings.SecurityExclusionList) +
-				conditionalEntry("security.inclusionList", ords.Spec.GlobalSettings.SecurityInclusionList) +
-				conditionalEntry("security.maxEntries", ords.Spec.GlobalSettings.SecurityMaxEntries) +
-				conditionalEntry("security.verifySSL", ords.Spec.GlobalSettings.SecurityVerifySSL) +
-				conditionalEntry("security.httpsHeaderCheck", ords.Spec.GlobalSettings.SecurityHTTPSHeaderCheck) +
-				conditionalEntry("security.forceHTTPS", ords.Spec.GlobalSettings.SecurityForceHTTPS) +
-				conditionalEntry("externalSessionTrustedOrigins", ords.Spec.GlobalSettings.SecuirtyExternalSessionTrustedOrigins) +
-				`  <entry key="standalone.doc.root">` + ordsSABase + `/config/global/doc_root/</entry>` + "\n" +
-				// Dynamic
-				defStandaloneAccessLog +
-				defMongoAccessLog +
-				defCert +
-				// Disabled (but not forgotten)
-				// conditionalEntry("standalone.binds", ords.Spec.GlobalSettings.StandaloneBinds) +
-				// conditionalEntry("error.externalPath", ords.Spec.GlobalSettings.ErrorExternalPath) +
-				// conditionalEntry("security.credentials.file ", ords.Spec.GlobalSettings.SecurityCredentialsFile) +
-				// conditionalEntry("standalone.static.path", ords.Spec.GlobalSettings.StandaloneStaticPath) +
-				// conditionalEntry("standalone.doc.root", ords.Spec.GlobalSettings.StandaloneDocRoot) +
-				// conditionalEntry("standalone.static.context.path", ords.Spec.GlobalSettings.StandaloneStaticContextPath) +
-				`</properties>`),
+			"settings.xml": props.String(),
 			"logging.properties": fmt.Sprintf(`handlers=java.util.logging.FileHandler` + "\n" +
 				`.level=SEVERE` + "\n" +
 				`java.util.logging.FileHandler.level=ALL` + "\n" +
@@ -142,79 +101,31 @@ func (r *RestDataServicesReconciler) ConfigMapDefine(ctx context.Context, ords *
 	} else {
 		// PoolConfigMap
 		poolName := strings.ToLower(ords.Spec.PoolSettings[poolIndex].PoolName)
-		var defDBNetworkPath string
+		props := ordsProperties{}
+		if err := props.setTyped(*ords.Spec.PoolSettings[poolIndex]); err != nil {
+			return nil, err
+		}
 		if ords.Spec.PoolSettings[poolIndex].DBWalletSecret != nil {
-			defDBNetworkPath = `  <entry key="db.wallet.zip.path">` + ordsSABase + `/config/databases/` + poolName + `/network/admin/` + ords.Spec.PoolSettings[poolIndex].DBWalletSecret.WalletName + `</entry>` + "\n" +
-				conditionalEntry("db.wallet.zip.service", strings.ToUpper(ords.Spec.PoolSettings[poolIndex].DBWalletZipService)) + "\n"
+			props.set("db.wallet.zip.path", ordsSABase+"/config/databases/"+poolName+"/network/admin/"+ords.Spec.PoolSettings[poolIndex].DBWalletSecret.WalletName)
+			props.set("db.wallet.zip.service", strings.ToUpper(ords.Spec.PoolSettings[poolIndex].DBWalletZipService))
 		} else {
-			defDBNetworkPath = `  <entry key="db.tnsDirectory">` + ordsSABase + `/config/databases/` + poolName + `/network/admin/</entry>` + "\n"
+			props.set("db.tnsDirectory", ordsSABase+"/config/databases/"+poolName+"/network/admin/")
 		}
+		// Settings without a field, such as db.serviceNameSuffix, are set from additionalSettings
+		props.setAdditional(ords.Spec.PoolSettings[poolIndex].AdditionalSettings)
+
 		defData = map[string]string{
-			"pool.xml": fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
-				`<!DOCTYPE properties SYSTEM "http://java.sun.com/dtd/properties.dtd">` + "\n" +
-				`<properties>` + "\n" +
-				`  <entry key="db.username">` + ords.Spec.PoolSettings[poolIndex].DBUsername + `</entry>` + "\n" +
-				conditionalEntry("db.adminUser", ords.Spec.PoolSettings[poolIndex].DBAdminUser) +
-				conditionalEntry("db.cdb.adminUser", ords.Spec.PoolSettings[poolIndex].DBCDBAdminUser) +
-				conditionalEntry("apex.security.administrator.roles", ords.Spec.PoolSettings[poolIndex].ApexSecurityAdministratorRoles) +
-				conditionalEntry("apex.security.user.roles", ords.Spec.PoolSettings[poolIndex].ApexSecurityUserRoles) +
-				conditionalEntry("db.credentialsSource", ords.Spec.PoolSettings[poolIndex].DBCredentialsSource) +
-				conditionalEntry("db.poolDestroyTimeout", ords.Spec.PoolSettings[poolIndex].DBPoolDestroyTimeout) +
-				conditionalEntry("debug.trackResources", ords.Spec.PoolSettings[poolIndex].DebugTrackResources) +
-				conditionalEntry("feature.openservicebroker.exclude", ords.Spec.PoolSettings[poolIndex].FeatureOpenservicebrokerExclude) +
-				conditionalEntry("feature.sdw", ords.Spec.PoolSettings[poolIndex].FeatureSDW) +
-				conditionalEntry("http.cookie.filter", ords.Spec.PoolSettings[poolIndex].HttpCookieFilter) +
-				conditionalEntry("jdbc.auth.admin.role", ords.Spec.PoolSettings[poolIndex].JDBCAuthAdminRole) +
-				conditionalEntry("jdbc.cleanup.mode", ords.Spec.PoolSettings[poolIndex].JDBCCleanupMode) +
-				conditionalEntry("owa.trace.sql", ords.Spec.PoolSettings[poolIndex].OwaTraceSql) +
-				conditionalEntry("plsql.gateway.mode", ords.Spec.PoolSettings[poolIndex].PlsqlGatewayMode) +
-				conditionalEntry("security.jwt.profile.enabled", ords.Spec.PoolSettings[poolIndex].SecurityJWTProfileEnabled) +
-				conditionalEntry("security.jwks.size", ords.Spec.PoolSettings[poolIndex].SecurityJWKSSize) +
-				conditionalEntry("security.jwks.connection.timeout", ords.Spec.PoolSettings[poolIndex].SecurityJWKSConnectionTimeout) +
-				conditionalEntry("security.jwks.read.timeout", ords.Spec.PoolSettings[poolIndex].SecurityJWKSReadTimeout) +
-				conditionalEntry("security.jwks.refresh.interval", ords.Spec.PoolSettings[poolIndex].SecurityJWKSRefreshInterval) +
-				conditionalEntry("security.jwt.allowed.skew", ords.Spec.PoolSettings[poolIndex].SecurityJWTAllowedSkew) +
-				conditionalEntry("security.jwt.allowed.age", ords.Spec.PoolSettings[poolIndex].SecurityJWTAllowedAge) +
-				conditionalEntry("db.connectionType", ords.Spec.PoolSettings[poolIndex].DBConnectionType) +
-				conditionalEntry("db.customURL", ords.Spec.PoolSettings[poolIndex].DBCustomURL) +
-				conditionalEntry("db.hostname", ords.Spec.PoolSettings[poolIndex].DBHostname) +
-				conditionalEntry("db.port", ords.Spec.PoolSettings[poolIndex].DBPort) +
-				conditionalEntry("db.servicename", ords.Spec.PoolSettings[poolIndex].DBServicename) +
-				conditionalEntry("db.sid", ords.Spec.PoolSettings[poolIndex].DBSid) +
-				conditionalEntry("db.tnsAliasName", ords.Spec.PoolSettings[poolIndex].DBTnsAliasName) +
-				conditionalEntry("jdbc.DriverType", ords.Spec.PoolSettings[poolIndex].JDBCDriverType) +
-				conditionalEntry("jdbc.InactivityTimeout", ords.Spec.PoolSettings[poolIndex].JDBCInactivityTimeout) +
-				conditionalEntry("jdbc.InitialLimit", ords.Spec.PoolSettings[poolIndex].JDBCInitialLimit) +
-				conditionalEntry("jdbc.MaxConnectionReuseCount", ords.Spec.PoolSettings[poolIndex].JDBCMaxConnectionReuseCount) +
-				conditionalEntry("jdbc.MaxLimit", ords.Spec.PoolSettings[poolIndex].JDBCMaxLimit) +
-				conditionalEntry("jdbc.auth.enabled", ords.Spec.PoolSettings[poolIndex].JDBCAuthEnabled) +
-				conditionalEntry("jdbc.MaxStatementsLimit", ords.Spec.PoolSettings[poolIndex].JDBCMaxStatementsLimit) +
-				conditionalEntry("jdbc.MinLimit", ords.Spec.PoolSettings[poolIndex].JDBCMinLimit) +
-				conditionalEntry("jdbc.statementTimeout", ords.Spec.PoolSettings[poolIndex].JDBCStatementTimeout) +
-				conditionalEntry("jdbc.MaxConnectionReuseTime", ords.Spec.PoolSettings[poolIndex].JDBCMaxConnectionReuseTime) +
-				conditionalEntry("jdbc.SecondsToTrustIdleConnection", ords.Spec.PoolSettings[poolIndex].JDBCSecondsToTrustIdleConnection) +
-				conditionalEntry("misc.defaultPage", ords.Spec.PoolSettings[poolIndex].MiscDefaultPage) +
-				conditionalEntry("misc.pagination.maxRows", ords.Spec.PoolSettings[poolIndex].MiscPaginationMaxRows) +
-				conditionalEntry("procedure.postProcess", ords.Spec.PoolSettings[poolIndex].ProcedurePostProcess) +
-				conditionalEntry("procedure.preProcess", ords.Spec.PoolSettings[poolIndex].ProcedurePreProcess) +
-				conditionalEntry("procedure.rest.preHook", ords.Spec.PoolSettings[poolIndex].ProcedureRestPreHook) +
-				conditionalEntry("security.requestAuthenticationFunction", ords.Spec.PoolSettings[poolIndex].SecurityRequestAuthenticationFunction) +
-				conditionalEntry("security.requestValidationFunction", ords.Spec.PoolSettings[poolIndex].SecurityRequestValidationFunction) +
-				conditionalEntry("soda.defaultLimit", ords.Spec.PoolSettings[poolIndex].SODADefaultLimit) +
-				conditionalEntry("soda.maxLimit", ords.Spec.PoolSettings[poolIndex].SODAMaxLimit) +
-				conditionalEntry("restEnabledSql.active", ords.Spec.PoolSettings[poolIndex].RestEnabledSqlActive) +
-				defDBNetworkPath +
-				// Disabled (but not forgotten)
-				// conditionalEntry("autoupgrade.api.aulocation", ords.Spec.PoolSettings[poolIndex].AutoupgradeAPIAulocation) +
-				// conditionalEntry("autoupgrade.api.enabled", ords.Spec.PoolSettings[poolIndex].AutoupgradeAPIEnabled) +
-				// conditionalEntry("autoupgrade.api.jvmlocation", ords.Spec.PoolSettings[poolIndex].AutoupgradeAPIJvmlocation) +
-				// conditionalEntry("autoupgrade.api.loglocation", ords.Spec.PoolSettings[poolIndex].AutoupgradeAPILoglocation) +
-				// conditionalEntry("db.serviceNameSuffix", ords.Spec.PoolSettings[poolIndex].DBServiceNameSuffix) +
-				`</properties>`),
+			"pool.xml": props.String(),
+		}
+		if ords.Spec.PoolSettings[poolIndex].DeletionPolicy == databasev1.DeletionPolicyUninstall {
+			// Kept with the ConfigMap to uninstall the pool once it is removed from the spec
+			poolSettings, _ := json.Marshal(ords.Spec.PoolSettings[poolIndex])
+			defAnnotations = map[string]string{uninstallAnnotation: string(poolSettings)}
 		}
 	}
 
 	objectMeta := objectMetaDefine(ords, configMapName)
+	objectMeta.Annotations = defAnnotations
 	def := &corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ConfigMap",
@@ -226,33 +137,37 @@ func (r *RestDataServicesReconciler) ConfigMapDefine(ctx context.Context, ords *
 
 	// Set the ownerRef
 	if err := ctrl.SetControllerReference(ords, def, r.Scheme); err != nil {
-		return nil
+		return nil, err
 	}
-	return def
+	return def, nil
 }
 
-func conditionalEntry(key string, value interface{}) string {
-	switch v := value.(type) {
-	case nil:
-		return ""
-	case string:
-		if v != "" {
-			return fmt.Sprintf(`  <entry key="%s">%s</entry>`+"\n", key, v)
-		}
-	case *int32:
-		if v != nil {
-			return fmt.Sprintf(`  <entry key="%s">%d</entry>`+"\n", key, *v)
-		}
-	case *bool:
-		if v != nil {
-			return fmt.Sprintf(`  <entry key="%s">%v</entry>`+"\n", key, *v)
+// initScript returns the init script from the spec.initScript ConfigMap, or the built-in script
+func (r *RestDataServicesReconciler) initScript(ctx context.Context, ords *databasev1.RestDataServices) (string, error) {
+	if ords.Spec.InitScript == nil {
+		return ordsInitScript, nil
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ords.Spec.InitScript.Name, Namespace: ords.Namespace}, configMap); err != nil {
+		return "", fmt.Errorf("unable to load init script from ConfigMap %s: %w", ords.Spec.InitScript.Name, err)
+	}
+	scriptData, exists := configMap.Data[ords.Spec.InitScript.Key]
+	if !exists || scriptData == "" {
+		return "", fmt.Errorf("unable to load init script from ConfigMap %s: key %s not found", ords.Spec.InitScript.Name, ords.Spec.InitScript.Key)
+	}
+	return scriptData, nil
+}
+
+// setAdditional sets the additionalSettings on the properties.  The webhook rejects settings that
+// have a typed field or are managed by the operator; should they get through, they are not set.
+func (p ordsProperties) setAdditional(settings map[string]string) {
+	for key, value := range settings {
+		if databasev1.IsOperatorManagedSetting(key) {
+			continue
 		}
-	case *time.Duration:
-		if v != nil {
-			return fmt.Sprintf(`  <entry key="%s">%v</entry>`+"\n", key, *v)
+		if _, exists := p[key]; exists {
+			continue
 		}
-	default:
-		return fmt.Sprintf(`  <entry key="%s">%v</entry>`+"\n", key, v)
+		p.set(key, value)
 	}
-	return ""
 }
@@ -0,0 +1,109 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Init script", func() {
+	ctx := context.Background()
+	var reconciler *RestDataServicesReconciler
+	var ords *databasev1.RestDataServices
+
+	initScriptConfigMap := func() (*corev1.ConfigMap, error) {
+		return reconciler.ConfigMapDefine(ctx, ords, ords.Name+"-init-script", 0)
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+		}
+		ords.Spec.SetDefaults()
+		userScript := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-script", Namespace: "default"},
+			Data:       map[string]string{"init.sh": "#!/bin/bash\nexit 0\n"},
+		}
+		reconciler = newFakeReconciler(ords, userScript)
+	})
+
+	It("should use the embedded script by default", func() {
+		configMap, err := initScriptConfigMap()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMap.Data["init_script.sh"]).To(HavePrefix("#!/bin/bash"))
+		Expect(configMap.Data["init_script.sh"]).To(Equal(ordsInitScript))
+	})
+
+	It("should use the script from the referenced ConfigMap", func() {
+		ords.Spec.InitScript = &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "user-script"}, Key: "init.sh"}
+		configMap, err := initScriptConfigMap()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMap.Data["init_script.sh"]).To(Equal("#!/bin/bash\nexit 0\n"))
+	})
+
+	It("should return an error when the referenced script cannot be loaded", func() {
+		ords.Spec.InitScript = &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "user-script"}, Key: "missing.sh"}
+		_, err := initScriptConfigMap()
+		Expect(err).To(MatchError(ContainSubstring("key missing.sh not found")))
+
+		ords.Spec.InitScript.Name = "missing"
+		err = reconciler.ConfigMapReconcile(ctx, ords, ords.Name+"-init-script", 0, nil, map[string]map[string]string{})
+		Expect(err).To(MatchError(ContainSubstring("ConfigMap missing")))
+	})
+
+	It("should map a ConfigMap to the resources using it as init script", func() {
+		ords.Spec.InitScript = &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "user-script"}, Key: "init.sh"}
+		Expect(reconciler.Update(ctx, ords)).To(Succeed())
+		Expect(reconciler.initScriptToRequests(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-script", Namespace: "default"},
+		})).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Name: "ords", Namespace: "default"}}))
+	})
+})
@@ -0,0 +1,206 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+const (
+	propertiesHeader = `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<!DOCTYPE properties SYSTEM "http://java.sun.com/dtd/properties.dtd">` + "\n"
+)
+
+// ordsProperties holds the ORDS settings of a Java properties XML file (settings.xml/pool.xml)
+type ordsProperties map[string]string
+
+// set the key when the value is not empty; pointers are only set when not nil
+func (p ordsProperties) set(key string, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+	case string:
+		if v != "" {
+			p[key] = v
+		}
+	case *int32:
+		if v != nil {
+			p[key] = fmt.Sprintf("%d", *v)
+		}
+	case *bool:
+		if v != nil {
+			p[key] = fmt.Sprintf("%v", *v)
+		}
+	case *databasev1.Duration:
+		if v != nil {
+			p[key] = v.ISO8601()
+		}
+	default:
+		p[key] = fmt.Sprintf("%v", v)
+	}
+}
+
+// Units of the ords tag option for durations that ORDS expects as a plain number
+var ordsTagUnits = map[string]time.Duration{
+	"milliseconds": time.Millisecond,
+	"seconds":      time.Second,
+}
+
+// setTyped sets every field of the settings struct under the ORDS setting named by its ords tag,
+// i.e. `ords:"mongo.idle.timeout,milliseconds"`; fields tagged "-" are skipped
+func (p ordsProperties) setTyped(settings interface{}) error {
+	v := reflect.ValueOf(settings)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key, option := parseOrdsTag(t.Field(i).Tag.Get("ords"))
+		if key == "" || key == "-" {
+			continue
+		}
+		value := v.Field(i).Interface()
+		if option == "" {
+			p.set(key, value)
+			continue
+		}
+		unit, known := ordsTagUnits[option]
+		d, isDuration := value.(*databasev1.Duration)
+		if !known || !isDuration {
+			return fmt.Errorf("field %s has an unknown ords tag option %q", t.Field(i).Name, option)
+		}
+		if d != nil {
+			p[key] = strconv.FormatInt(int64(d.Duration/unit), 10)
+		}
+	}
+	return nil
+}
+
+// parseOrdsTag splits an ords tag into the setting key and its option
+func parseOrdsTag(tag string) (key string, option string) {
+	key, option, _ = strings.Cut(tag, ",")
+	return key, option
+}
+
+// String renders the properties as Java properties XML with the entries sorted by key
+func (p ordsProperties) String() string {
+	keys := make([]string, 0, len(p))
+	for key := range p {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(propertiesHeader)
+	b.WriteString("<properties>\n")
+	for _, key := range keys {
+		b.WriteString(`  <entry key="`)
+		b.WriteString(escapeXML(key))
+		b.WriteString(`">`)
+		b.WriteString(escapeXML(p[key]))
+		b.WriteString("</entry>\n")
+	}
+	b.WriteString("</properties>")
+	return b.String()
+}
+
+func escapeXML(s string) string {
+	var b bytes.Buffer
+	// EscapeText only errors when the writer does
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// xmlProperties maps the properties.dtd for parsing
+type xmlProperties struct {
+	XMLName xml.Name `xml:"properties"`
+	Entries []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"entry"`
+}
+
+// parseProperties reads Java properties XML; later entries of the same key win as with java.util.Properties
+func parseProperties(data string) (ordsProperties, error) {
+	var parsed xmlProperties
+	if err := xml.Unmarshal([]byte(data), &parsed); err != nil {
+		return nil, err
+	}
+	props := ordsProperties{}
+	for _, entry := range parsed.Entries {
+		props[entry.Key] = entry.Value
+	}
+	return props, nil
+}
+
+// configMapDataEqual compares ConfigMap data, comparing properties XML by its settings rather than its bytes
+func configMapDataEqual(defined, desired map[string]string) bool {
+	if len(defined) != len(desired) {
+		return false
+	}
+	for key, desiredValue := range desired {
+		definedValue, exists := defined[key]
+		if !exists {
+			return false
+		}
+		if definedValue == desiredValue {
+			continue
+		}
+		if !strings.HasSuffix(key, ".xml") {
+			return false
+		}
+		definedProps, err := parseProperties(definedValue)
+		if err != nil {
+			return false
+		}
+		desiredProps, err := parseProperties(desiredValue)
+		if err != nil {
+			return false
+		}
+		if !reflect.DeepEqual(definedProps, desiredProps) {
+			return false
+		}
+	}
+	return true
+}
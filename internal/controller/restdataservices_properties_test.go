@@ -0,0 +1,195 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("ORDS properties", func() {
+	It("should escape and round-trip values", func() {
+		props := ordsProperties{
+			"security.exclusionList": `a&b<c>"d"'e'`,
+			"db.customURL":           "jdbc:oracle:thin:@(description=(address=(host=db&1)))",
+		}
+		parsed, err := parseProperties(props.String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(props))
+		Expect(props.String()).To(ContainSubstring(`<entry key="security.exclusionList">a&amp;b&lt;c&gt;&#34;d&#34;&#39;e&#39;</entry>`))
+	})
+
+	It("should render entries in key order", func() {
+		props := ordsProperties{"b.key": "2", "a.key": "1"}
+		Expect(props.String()).To(Equal(propertiesHeader + "<properties>\n" +
+			`  <entry key="a.key">1</entry>` + "\n" +
+			`  <entry key="b.key">2</entry>` + "\n" +
+			"</properties>"))
+	})
+
+	It("should only set values that are defined", func() {
+		props := ordsProperties{}
+		props.set("empty", "")
+		props.set("nil.int", (*int32)(nil))
+		props.set("int", &[]int32{8080}[0])
+		props.set("bool", &[]bool{false}[0])
+		Expect(props).To(Equal(ordsProperties{"int": "8080", "bool": "false"}))
+	})
+
+	It("should parse properties written by hand", func() {
+		parsed, err := parseProperties(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE properties SYSTEM "http://java.sun.com/dtd/properties.dtd">
+<properties>
+<comment>Saved by ORDS</comment>
+<entry key="db.username">ORDS_PUBLIC_USER</entry>
+<entry key="db.port">1521</entry>
+</properties>`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(ordsProperties{"db.username": "ORDS_PUBLIC_USER", "db.port": "1521"}))
+	})
+
+	It("should compare ConfigMap data semantically", func() {
+		desired := map[string]string{
+			"pool.xml":           ordsProperties{"db.username": "ORDS_PUBLIC_USER", "db.port": "1521"}.String(),
+			"logging.properties": ".level=SEVERE",
+		}
+		reordered := map[string]string{
+			"pool.xml": `<properties><entry key="db.port">1521</entry>` +
+				`<entry key="db.username">ORDS_PUBLIC_USER</entry></properties>`,
+			"logging.properties": ".level=SEVERE",
+		}
+		Expect(configMapDataEqual(reordered, desired)).To(BeTrue())
+
+		reordered["pool.xml"] = `<properties><entry key="db.port">1522</entry></properties>`
+		Expect(configMapDataEqual(reordered, desired)).To(BeFalse())
+
+		reordered["pool.xml"] = desired["pool.xml"]
+		reordered["logging.properties"] = ".level=FINE"
+		Expect(configMapDataEqual(reordered, desired)).To(BeFalse())
+	})
+
+	DescribeTable("should map every settings field to exactly one ORDS setting",
+		func(settings interface{}) {
+			t := reflect.TypeOf(settings)
+			populated := reflect.New(t).Elem()
+			mapped := make(map[string]string)
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				tag, tagged := field.Tag.Lookup("ords")
+				Expect(tagged).To(BeTrue(), "field %s has no ords tag", field.Name)
+				key, option := parseOrdsTag(tag)
+				Expect(key).NotTo(BeEmpty(), "field %s has an empty ords tag", field.Name)
+				if key == "-" {
+					continue
+				}
+				Expect(mapped).NotTo(HaveKey(key), "field %s maps to %s already used by %s", field.Name, key, mapped[key])
+				mapped[key] = field.Name
+				if option != "" {
+					Expect(ordsTagUnits).To(HaveKey(option), "field %s has an unknown ords tag option", field.Name)
+					Expect(field.Type).To(Equal(reflect.TypeOf(&databasev1.Duration{})), "field %s is not a Duration", field.Name)
+				}
+
+				// Populate the field to check that it is rendered
+				value := populated.Field(i)
+				switch value.Interface().(type) {
+				case string:
+					value.SetString("value")
+				case bool:
+					value.SetBool(true)
+				case *bool:
+					value.Set(reflect.ValueOf(&[]bool{true}[0]))
+				case *int32:
+					value.Set(reflect.ValueOf(&[]int32{1}[0]))
+				case *databasev1.Duration:
+					value.Set(reflect.ValueOf(&databasev1.Duration{Duration: time.Second}))
+				default:
+					Fail("field " + field.Name + " has a type that cannot be rendered")
+				}
+			}
+
+			props := ordsProperties{}
+			Expect(props.setTyped(populated.Interface())).To(Succeed())
+			Expect(props).To(HaveLen(len(mapped)))
+			for key := range mapped {
+				Expect(props).To(HaveKey(key))
+			}
+		},
+		Entry("GlobalSettings", databasev1.GlobalSettings{}),
+		Entry("PoolSettings", databasev1.PoolSettings{}),
+	)
+
+	It("should render settings under their ORDS keys and units", func() {
+		global := ordsProperties{}
+		Expect(global.setTyped(databasev1.GlobalSettings{
+			FeatureGraphQLMaxNestingDepth:         &[]int32{5}[0],
+			CacheMetadataGraphQLExpireAfterWrite:  &databasev1.Duration{Duration: 90 * time.Minute},
+			MongoIdleTimeout:                      &databasev1.Duration{Duration: 2 * time.Second},
+			SecuirtyExternalSessionTrustedOrigins: "https://example.com",
+		})).To(Succeed())
+		Expect(global).To(HaveKeyWithValue("feature.graphql.max.nesting.depth", "5"))
+		Expect(global).To(HaveKeyWithValue("cache.metadata.graphql.expireAfterWrite", "PT1H30M"))
+		Expect(global).To(HaveKeyWithValue("mongo.idle.timeout", "2000"))
+		Expect(global).To(HaveKeyWithValue("security.externalSessionTrustedOrigins", "https://example.com"))
+
+		pool := ordsProperties{}
+		Expect(pool.setTyped(databasev1.PoolSettings{
+			ProcedurePostProcess:   "audit.post",
+			SecurityJWTAllowedAge:  &databasev1.Duration{Duration: time.Hour},
+			SecurityJWTAllowedSkew: &databasev1.Duration{Duration: 5 * time.Second},
+		})).To(Succeed())
+		Expect(pool).To(HaveKeyWithValue("procedure.postProcess", "audit.post"))
+		Expect(pool).To(HaveKeyWithValue("security.jwt.allowed.age", "3600"))
+		Expect(pool).To(HaveKeyWithValue("security.jwt.allowed.skew", "5"))
+	})
+
+	It("should reject unknown ords tag options", func() {
+		props := ordsProperties{}
+		Expect(props.setTyped(struct {
+			Timeout *databasev1.Duration `ords:"timeout,fortnights"`
+		}{Timeout: &databasev1.Duration{Duration: time.Hour}})).To(MatchError(ContainSubstring("unknown ords tag option")))
+		Expect(props.setTyped(struct {
+			Port *int32 `ords:"port,seconds"`
+		}{Port: &[]int32{1}[0]})).To(MatchError(ContainSubstring("unknown ords tag option")))
+	})
+})
@@ -0,0 +1,304 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+// initResults are written as JSON by ords_init.sh to the termination message: the version of the
+// ORDS software running the script and the result of each pool
+type initResults struct {
+	ORDSVersion string       `json:"ordsVersion"`
+	Pools       []initResult `json:"pools"`
+}
+
+// initResult is the result of a pool
+type initResult struct {
+	Pool        string `json:"pool"`
+	ExitCode    int    `json:"exitCode"`
+	Result      string `json:"result"`
+	Action      string `json:"action"`
+	DBType      string `json:"dbType"`
+	ORDSVersion string `json:"ordsVersion"`
+	APEXVersion string `json:"apexVersion"`
+	Connected   bool   `json:"connected"`
+	Error       string `json:"error"`
+	// FinishedAt is when the container writing the result terminated
+	FinishedAt metav1.Time `json:"-"`
+}
+
+// setSecretsAction is the action of the init container, which does not connect to the database
+const setSecretsAction = "setSecrets"
+
+// parseInitResults parses the termination message of a container running ords_init.sh
+func parseInitResults(message string, finishedAt metav1.Time) (*initResults, error) {
+	results := &initResults{}
+	if err := json.Unmarshal([]byte(message), results); err != nil {
+		return nil, err
+	}
+	for i := range results.Pools {
+		results.Pools[i].Pool = strings.ToLower(results.Pools[i].Pool)
+		results.Pools[i].FinishedAt = finishedAt
+	}
+	return results, nil
+}
+
+// jobResults returns the results of the last terminated pod of a Job; none when its termination message
+// is not a result, i.e. written by a spec.initScript, as the Job state is reported regardless
+func (r *RestDataServicesReconciler) jobResults(ctx context.Context, job *batchv1.Job) ([]initResult, error) {
+	logr := log.FromContext(ctx).WithName("jobResults")
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return nil, err
+	}
+	var terminated *corev1.ContainerStateTerminated
+	for _, pod := range podList.Items {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			state := terminatedState(containerStatus)
+			if state == nil || state.Message == "" {
+				continue
+			}
+			if terminated == nil || state.FinishedAt.After(terminated.FinishedAt.Time) {
+				terminated = state
+			}
+		}
+	}
+	if terminated == nil {
+		return nil, nil
+	}
+	results, err := parseInitResults(terminated.Message, terminated.FinishedAt)
+	if err != nil {
+		logr.Info("Unable to parse the termination message of Job " + job.Name + ": " + err.Error())
+		return nil, nil
+	}
+	return results.Pools, nil
+}
+
+// terminatedState returns the state of the container when it terminated last, if it has
+func terminatedState(containerStatus corev1.ContainerStatus) *corev1.ContainerStateTerminated {
+	if containerStatus.State.Terminated != nil {
+		return containerStatus.State.Terminated
+	}
+	return containerStatus.LastTerminationState.Terminated
+}
+
+/************************************************
+ * Init Results
+ *************************************************/
+// InitReconcile reports the init container results of the Workload pods in the Initialized condition, with an
+// Event for each pool failing to initialize, and in the pool status.  It returns false when a pool failed to initialize.
+func (r *RestDataServicesReconciler) InitReconcile(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices) (bool, error) {
+	logr := log.FromContext(ctx).WithName("InitReconcile")
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(req.Namespace), client.MatchingLabels(getLabels(ords.Name))); err != nil {
+		return false, err
+	}
+	failures := make(map[string]string)
+	runningVersions := make(map[string]bool)
+	poolResults := make(map[string]initResult)
+	var reported bool
+	for _, pod := range podList.Items {
+		for _, containerStatus := range pod.Status.InitContainerStatuses {
+			terminated := terminatedState(containerStatus)
+			if terminated == nil || terminated.Message == "" {
+				continue
+			}
+			results, err := parseInitResults(terminated.Message, terminated.FinishedAt)
+			if err != nil {
+				logr.Info("Unable to parse the termination message of pod " + pod.Name + ": " + err.Error())
+				continue
+			}
+			reported = true
+			if pod.Status.Phase == corev1.PodRunning && results.ORDSVersion != "" {
+				runningVersions[results.ORDSVersion] = true
+			}
+			for _, result := range results.Pools {
+				if latest, exists := poolResults[result.Pool]; !exists || result.FinishedAt.After(latest.FinishedAt.Time) {
+					poolResults[result.Pool] = result
+				}
+				if result.ExitCode == 0 || failures[result.Pool] != "" {
+					continue
+				}
+				failures[result.Pool] = fmt.Sprintf("pool %s (pod %s): %s", result.Pool, pod.Name, result.Error)
+			}
+		}
+	}
+	if err := r.ORDSVersionReconcile(ctx, req, ords, runningVersions); err != nil {
+		return false, err
+	}
+	if err := r.PoolStatusReconcile(ctx, req, ords, poolResults); err != nil {
+		return false, err
+	}
+	if !reported {
+		return true, nil
+	}
+
+	condition := metav1.Condition{Type: typeInitializedORDS, Status: metav1.ConditionTrue, Reason: "Initialized",
+		Message: "Pools initialized"}
+	if len(failures) > 0 {
+		poolNames := make([]string, 0, len(failures))
+		for poolName := range failures {
+			poolNames = append(poolNames, poolName)
+		}
+		sort.Strings(poolNames)
+		messages := make([]string, 0, len(poolNames))
+		for _, poolName := range poolNames {
+			messages = append(messages, failures[poolName])
+		}
+		condition = metav1.Condition{Type: typeInitializedORDS, Status: metav1.ConditionFalse, Reason: "InitFailed",
+			Message: "Failed to initialize " + strings.Join(messages, "; ")}
+
+		// Only emit the Events when the failures change, not on every reconcile
+		previous := meta.FindStatusCondition(ords.Status.Conditions, typeInitializedORDS)
+		if previous == nil || previous.Message != condition.Message {
+			for _, message := range messages {
+				logr.Info("Failed to initialize " + message)
+				r.Recorder.Eventf(ords, corev1.EventTypeWarning, "InitFailed", "Failed to initialize %s", message)
+			}
+		}
+	}
+	if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+		return false, err
+	}
+	return len(failures) == 0, nil
+}
+
+// ORDSVersionReconcile sets the status ORDS version to the versions reported by the running pods, rather than trusting
+// the image tag; more than one while rolling out a new image.  It is kept while no pods are running.
+func (r *RestDataServicesReconciler) ORDSVersionReconcile(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices, runningVersions map[string]bool) error {
+	if len(runningVersions) == 0 {
+		return nil
+	}
+	versions := make([]string, 0, len(runningVersions))
+	for version := range runningVersions {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	ordsVersion := strings.Join(versions, ", ")
+	if ords.Status.ORDSVersion == ordsVersion {
+		return nil
+	}
+	if err := r.getDefaulted(ctx, req.NamespacedName, ords); err != nil {
+		return err
+	}
+	ords.Status.ORDSVersion = ordsVersion
+	return r.Status().Update(ctx, ords)
+}
+
+/************************************************
+ * Pool Status
+ *************************************************/
+// PoolStatusReconcile sets the status of each pool, applying the latest results by pool name; results older than
+// the last actions of the pool, i.e. of an install Job superseded by the init container or vice versa, are ignored
+func (r *RestDataServicesReconciler) PoolStatusReconcile(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices, results map[string]initResult) error {
+	if err := r.getDefaulted(ctx, req.NamespacedName, ords); err != nil {
+		return err
+	}
+
+	definedPools := make(map[string]databasev1.PoolStatus)
+	for _, poolStatus := range ords.Status.Pools {
+		definedPools[strings.ToLower(poolStatus.PoolName)] = poolStatus
+	}
+	pools := make([]databasev1.PoolStatus, 0, len(ords.Spec.PoolSettings))
+	for _, pool := range ords.Spec.PoolSettings {
+		poolName := strings.ToLower(pool.PoolName)
+		poolStatus, exists := definedPools[poolName]
+		if !exists {
+			poolStatus = databasev1.PoolStatus{PoolName: pool.PoolName}
+		}
+		if result, exists := results[poolName]; exists {
+			applyInitResult(&poolStatus, result)
+		}
+		pools = append(pools, poolStatus)
+	}
+	if equality.Semantic.DeepEqual(ords.Status.Pools, pools) {
+		return nil
+	}
+	ords.Status.Pools = pools
+	return r.Status().Update(ctx, ords)
+}
+
+// applyInitResult sets the pool status from the result of its install Job or init container
+func applyInitResult(poolStatus *databasev1.PoolStatus, result initResult) {
+	if poolStatus.LastActionTime != nil && result.FinishedAt.Before(poolStatus.LastActionTime) {
+		return
+	}
+	if result.DBType != "" {
+		poolStatus.DBType = result.DBType
+	}
+	poolStatus.LastAction = result.Action
+	poolStatus.LastActionResult = result.Result
+	poolStatus.LastActionError = result.Error
+	// The init container does not check the database connectivity
+	connectionChecked := result.Action != setSecretsAction
+	if !result.FinishedAt.IsZero() {
+		finishedAt := result.FinishedAt
+		poolStatus.LastActionTime = &finishedAt
+		if connectionChecked {
+			poolStatus.LastConnectionCheck = &finishedAt
+		}
+	}
+	if !connectionChecked {
+		return
+	}
+	poolStatus.ConnectionError = ""
+	if !result.Connected {
+		poolStatus.ConnectionError = result.Error
+		return
+	}
+	poolStatus.ORDSVersion = result.ORDSVersion
+	poolStatus.APEXVersion = result.APEXVersion
+}
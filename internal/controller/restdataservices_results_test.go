@@ -0,0 +1,166 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Init results", func() {
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "ords", Namespace: "default"}}
+	var reconciler *RestDataServicesReconciler
+	var ords *databasev1.RestDataServices
+
+	initPod := func(name string, phase corev1.PodPhase, message string) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: getLabels(ords.Name)},
+			Status: corev1.PodStatus{Phase: phase, InitContainerStatuses: []corev1.ContainerStatus{{
+				Name: ords.Name + "-init",
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1, Message: message, FinishedAt: metav1.Now()}},
+			}}},
+		}
+		Expect(reconciler.Create(ctx, pod)).To(Succeed())
+	}
+
+	initialize := func() bool {
+		initialized, err := reconciler.InitReconcile(ctx, req, ords)
+		Expect(err).NotTo(HaveOccurred())
+		return initialized
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+			Spec: databasev1.RestDataServicesSpec{
+				Image:        "container-registry.oracle.com/database/ords:24.1.0",
+				PoolSettings: []*databasev1.PoolSettings{{PoolName: "default"}, {PoolName: "pdb1"}},
+			},
+		}
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+	})
+
+	It("should not report before the init containers terminate", func() {
+		Expect(initialize()).To(BeTrue())
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typeInitializedORDS)).To(BeNil())
+	})
+
+	It("should report the initialized pools", func() {
+		initPod("ords-abcde", corev1.PodRunning, `{"ordsVersion":"24.1.0.r1234","pools":[`+
+			`{"pool":"default","exitCode":0,"result":"Succeeded","action":"setSecrets"},`+
+			`{"pool":"pdb1","exitCode":0,"result":"Succeeded","action":"setSecrets"}]}`)
+		Expect(initialize()).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(ords.Status.Conditions, typeInitializedORDS)).To(BeTrue())
+	})
+
+	It("should name the failing pool and reason", func() {
+		message := `{"ordsVersion":"24.1.0.r1234","pools":[` +
+			`{"pool":"default","exitCode":0,"result":"Succeeded","action":"setSecrets"},` +
+			`{"pool":"pdb1","exitCode":1,"result":"Failed","action":"setSecrets","error":"db.password must be specified for pdb1"}]}`
+		initPod("ords-abcde", corev1.PodPending, message)
+		initPod("ords-fghij", corev1.PodPending, message)
+		Expect(initialize()).To(BeFalse())
+		condition := meta.FindStatusCondition(ords.Status.Conditions, typeInitializedORDS)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(Equal("Failed to initialize pool pdb1 (pod ords-abcde): db.password must be specified for pdb1"))
+
+		events := reconciler.Recorder.(*record.FakeRecorder).Events
+		Expect(events).To(Receive(ContainSubstring("InitFailed Failed to initialize pool pdb1")))
+		Expect(initialize()).To(BeFalse())
+		Expect(events).NotTo(Receive())
+	})
+
+	It("should report the pool status of pools without an admin secret", func() {
+		initPod("ords-abcde", corev1.PodPending, `{"ordsVersion":"24.1.0.r1234","pools":[`+
+			`{"pool":"default","exitCode":0,"result":"Succeeded","action":"setSecrets"},`+
+			`{"pool":"pdb1","exitCode":1,"result":"Failed","action":"setSecrets","error":"db.password must be specified for pdb1"}]}`)
+		Expect(initialize()).To(BeFalse())
+		Expect(ords.Status.Pools).To(HaveLen(2))
+		Expect(ords.Status.Pools[0].LastAction).To(Equal("setSecrets"))
+		Expect(ords.Status.Pools[0].LastActionResult).To(Equal("Succeeded"))
+		Expect(ords.Status.Pools[0].LastActionTime).NotTo(BeNil())
+		Expect(ords.Status.Pools[0].LastConnectionCheck).To(BeNil())
+		Expect(ords.Status.Pools[1].LastActionResult).To(Equal("Failed"))
+		Expect(ords.Status.Pools[1].LastActionError).To(Equal("db.password must be specified for pdb1"))
+		Expect(ords.Status.Pools[1].ConnectionError).To(BeEmpty())
+
+		// The result of an install Job finished before the init container is superseded
+		superseded := initResult{Pool: "default", Result: "Failed", Action: "ordsInstall", Connected: true,
+			FinishedAt: metav1.NewTime(ords.Status.Pools[0].LastActionTime.Add(-time.Minute))}
+		Expect(reconciler.PoolStatusReconcile(ctx, req, ords, map[string]initResult{"default": superseded})).To(Succeed())
+		Expect(ords.Status.Pools[0].LastAction).To(Equal("setSecrets"))
+		Expect(ords.Status.Pools[0].LastActionResult).To(Equal("Succeeded"))
+	})
+
+	It("should ignore termination messages that are not results", func() {
+		initPod("ords-abcde", corev1.PodPending, "OOMKilled")
+		Expect(initialize()).To(BeTrue())
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typeInitializedORDS)).To(BeNil())
+	})
+
+	It("should report the ORDS version of the running pods", func() {
+		ords.Status.ORDSVersion = "24.1.0.r1234"
+		Expect(reconciler.Status().Update(ctx, ords)).To(Succeed())
+		Expect(initialize()).To(BeTrue())
+		Expect(ords.Status.ORDSVersion).To(Equal("24.1.0.r1234"))
+
+		initPod("ords-abcde", corev1.PodRunning, `{"ordsVersion":"24.1.1.r5678","pools":[]}`)
+		initPod("ords-fghij", corev1.PodPending, `{"ordsVersion":"24.2.0.r9012","pools":[]}`)
+		Expect(initialize()).To(BeTrue())
+		Expect(ords.Status.ORDSVersion).To(Equal("24.1.1.r5678"))
+
+		initPod("ords-klmno", corev1.PodRunning, `{"ordsVersion":"24.2.0.r9012","pools":[]}`)
+		Expect(initialize()).To(BeTrue())
+		Expect(ords.Status.ORDSVersion).To(Equal("24.1.1.r5678, 24.2.0.r9012"))
+	})
+})
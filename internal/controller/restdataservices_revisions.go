@@ -0,0 +1,207 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+const (
+	// Labels the revision ConfigMaps with their revision number
+	configRevisionLabel = "oracle.com/ords-operator-config-revision"
+	// Names the revision to roll back to, when spec.configRevisions.rollbackTo is not set
+	rollbackAnnotation = "oracle.com/ords-operator-rollback-to"
+	// The revision ConfigMap key holding the data of the settings ConfigMaps by name, as JSON
+	configRevisionDataKey = "config.json"
+)
+
+/************************************************
+ * Configuration Revisions
+ *************************************************/
+// configRollbackTarget returns the name of the revision the configuration is rolled back to, if any
+func configRollbackTarget(ords *databasev1.RestDataServices) string {
+	if ords.Spec.ConfigRevisions.RollbackTo != "" {
+		return ords.Spec.ConfigRevisions.RollbackTo
+	}
+	return ords.Annotations[rollbackAnnotation]
+}
+
+// ConfigRollbackLoad returns the data of the settings ConfigMaps of the revision rolled back to, nil when not rolled back
+func (r *RestDataServicesReconciler) ConfigRollbackLoad(ctx context.Context, ords *databasev1.RestDataServices) (map[string]map[string]string, error) {
+	target := configRollbackTarget(ords)
+	if target == "" {
+		return nil, nil
+	}
+	revision := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: target, Namespace: ords.Namespace}, revision); err != nil {
+		return nil, fmt.Errorf("unable to get configuration revision %s: %w", target, err)
+	}
+	if _, ok := revision.Labels[configRevisionLabel]; !ok || !metav1.IsControlledBy(revision, ords) {
+		return nil, fmt.Errorf("%s is not a configuration revision of %s", target, ords.Name)
+	}
+	config := make(map[string]map[string]string)
+	if err := json.Unmarshal([]byte(revision.Data[configRevisionDataKey]), &config); err != nil {
+		return nil, fmt.Errorf("unable to parse configuration revision %s: %w", target, err)
+	}
+	return config, nil
+}
+
+// ConfigRevisionReconcile records the rendered data of the settings ConfigMaps as a revision when it has not been
+// recorded before, prunes the revisions beyond the history limit and reports them in the status
+func (r *RestDataServicesReconciler) ConfigRevisionReconcile(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices, configMapNames []string, renderedConfig map[string]map[string]string) error {
+	logr := log.FromContext(ctx).WithName("ConfigRevisionReconcile")
+
+	config := make(map[string]map[string]string)
+	for _, configMapName := range configMapNames {
+		config[configMapName] = renderedConfig[configMapName]
+	}
+	configHash := generateSpecHash(config)
+
+	revisionList := &corev1.ConfigMapList{}
+	if err := r.List(ctx, revisionList, client.InNamespace(ords.Namespace),
+		client.MatchingLabels(getLabels(ords.Name)), client.HasLabels{configRevisionLabel}); err != nil {
+		return err
+	}
+	revisions := make([]corev1.ConfigMap, 0, len(revisionList.Items)+1)
+	var current *corev1.ConfigMap
+	var latest int64
+	for i := range revisionList.Items {
+		revision := revisionList.Items[i]
+		number, err := strconv.ParseInt(revision.Labels[configRevisionLabel], 10, 64)
+		if err != nil {
+			logr.Info("Ignoring revision with invalid number: " + revision.Name)
+			continue
+		}
+		latest = max(latest, number)
+		revisions = append(revisions, revision)
+	}
+	for i := range revisions {
+		if revisions[i].Annotations[configHashAnnotation] == configHash {
+			current = &revisions[i]
+		}
+	}
+
+	// Record the configuration; a rollback renders a recorded configuration
+	if current == nil {
+		data, err := json.Marshal(config)
+		if err != nil {
+			return err
+		}
+		revision := configRevisionDefine(ords, latest+1, configHash, string(data))
+		if err := ctrl.SetControllerReference(ords, revision, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, revision); err != nil {
+			return err
+		}
+		logr.Info("Created: " + revision.Name)
+		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "ConfigRevision", "Configuration revision %s recorded", revision.Name)
+		revisions = append(revisions, *revision)
+		current = &revisions[len(revisions)-1]
+	}
+	currentName := current.Name
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisionNumber(&revisions[i]) > revisionNumber(&revisions[j])
+	})
+
+	// Prune the oldest revisions, keeping the current and the one rolled back to
+	target := configRollbackTarget(ords)
+	configRevisions := make([]databasev1.ConfigRevision, 0, len(revisions))
+	for i := range revisions {
+		revision := &revisions[i]
+		if i >= int(ords.Spec.ConfigRevisions.HistoryLimit) && revision.Name != currentName && revision.Name != target {
+			if err := r.Delete(ctx, revision); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			logr.Info("Deleted: " + revision.Name)
+			continue
+		}
+		configRevisions = append(configRevisions, databasev1.ConfigRevision{
+			Name:              revision.Name,
+			Revision:          revisionNumber(revision),
+			ConfigHash:        revision.Annotations[configHashAnnotation],
+			CreationTimestamp: revision.CreationTimestamp,
+		})
+	}
+
+	if err := r.getDefaulted(ctx, req.NamespacedName, ords); err != nil {
+		return err
+	}
+	if target != "" && currentName == target && ords.Status.CurrentConfigRevision != currentName {
+		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Rollback", "Configuration rolled back to revision %s", target)
+	}
+	if ords.Status.CurrentConfigRevision == currentName && equality.Semantic.DeepEqual(ords.Status.ConfigRevisions, configRevisions) {
+		return nil
+	}
+	ords.Status.CurrentConfigRevision = currentName
+	ords.Status.ConfigRevisions = configRevisions
+	return r.Status().Update(ctx, ords)
+}
+
+// configRevisionDefine returns the immutable ConfigMap of a revision of the rendered configuration
+func configRevisionDefine(ords *databasev1.RestDataServices, number int64, configHash string, data string) *corev1.ConfigMap {
+	objectMeta := objectMetaDefine(ords, fmt.Sprintf("%s-config-rev-%d", ords.Name, number))
+	objectMeta.Labels[configRevisionLabel] = strconv.FormatInt(number, 10)
+	objectMeta.Annotations = map[string]string{configHashAnnotation: configHash}
+	immutable := true
+	return &corev1.ConfigMap{
+		ObjectMeta: objectMeta,
+		Immutable:  &immutable,
+		Data:       map[string]string{configRevisionDataKey: data},
+	}
+}
+
+func revisionNumber(revision *corev1.ConfigMap) int64 {
+	number, _ := strconv.ParseInt(revision.Labels[configRevisionLabel], 10, 64)
+	return number
+}
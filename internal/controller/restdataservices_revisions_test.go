@@ -0,0 +1,155 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Configuration revisions", func() {
+	ctx := context.Background()
+	var reconciler *RestDataServicesReconciler
+	var recorder *record.FakeRecorder
+	var ords *databasev1.RestDataServices
+	var req ctrl.Request
+	var globalConfigMap string
+
+	// reconcileConfig renders the Global ConfigMap, rolled back if requested, and records its revision
+	reconcileConfig := func(maxLimit string) {
+		ords.Spec.PoolSettings = nil
+		ords.Spec.GlobalSettings.AdditionalSettings = map[string]string{"jdbc.MaxLimit": maxLimit}
+		rollbackConfig, err := reconciler.ConfigRollbackLoad(ctx, ords)
+		Expect(err).NotTo(HaveOccurred())
+		renderedConfig := make(map[string]map[string]string)
+		Expect(reconciler.ConfigMapReconcile(ctx, ords, globalConfigMap, 0, rollbackConfig, renderedConfig)).To(Succeed())
+		Expect(reconciler.ConfigRevisionReconcile(ctx, req, ords, []string{globalConfigMap}, renderedConfig)).To(Succeed())
+	}
+
+	renderedMaxLimit := func() string {
+		configMap := &corev1.ConfigMap{}
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: globalConfigMap, Namespace: ords.Namespace}, configMap)).To(Succeed())
+		return configMap.Data["settings.xml"]
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+			Spec:       databasev1.RestDataServicesSpec{Image: "container-registry.oracle.com/database/ords:24.1.0"},
+		}
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+		recorder = reconciler.Recorder.(*record.FakeRecorder)
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}}
+		globalConfigMap = ords.Name + "-" + globalConfigMapName
+	})
+
+	It("should record a revision for each rendered configuration", func() {
+		reconcileConfig("20")
+		reconcileConfig("20")
+		Expect(ords.Status.ConfigRevisions).To(HaveLen(1))
+		Expect(ords.Status.CurrentConfigRevision).To(Equal("ords-config-rev-1"))
+
+		reconcileConfig("40")
+		Expect(ords.Status.ConfigRevisions).To(HaveLen(2))
+		Expect(ords.Status.ConfigRevisions[0].Name).To(Equal("ords-config-rev-2"))
+		Expect(ords.Status.ConfigRevisions[0].Revision).To(Equal(int64(2)))
+		Expect(ords.Status.CurrentConfigRevision).To(Equal("ords-config-rev-2"))
+
+		revision := &corev1.ConfigMap{}
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: "ords-config-rev-1", Namespace: ords.Namespace}, revision)).To(Succeed())
+		Expect(*revision.Immutable).To(BeTrue())
+		Expect(metav1.IsControlledBy(revision, ords)).To(BeTrue())
+		Expect(revision.Data[configRevisionDataKey]).To(ContainSubstring(`jdbc.MaxLimit`))
+		Eventually(recorder.Events).Should(Receive(ContainSubstring("Configuration revision ords-config-rev-2 recorded")))
+	})
+
+	It("should keep the revisions of the history limit", func() {
+		for _, maxLimit := range []string{"10", "20", "30", "40"} {
+			ords.Spec.ConfigRevisions.HistoryLimit = 2
+			reconcileConfig(maxLimit)
+		}
+		Expect(ords.Status.ConfigRevisions).To(HaveLen(2))
+		Expect(ords.Status.ConfigRevisions[1].Name).To(Equal("ords-config-rev-3"))
+		err := reconciler.Get(ctx, types.NamespacedName{Name: "ords-config-rev-1", Namespace: ords.Namespace}, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should render the revision rolled back to until unset", func() {
+		reconcileConfig("20")
+		reconcileConfig("40")
+		Expect(renderedMaxLimit()).To(ContainSubstring(`"jdbc.MaxLimit">40<`))
+
+		ords.Spec.ConfigRevisions.RollbackTo = "ords-config-rev-1"
+		reconcileConfig("40")
+		Expect(renderedMaxLimit()).To(ContainSubstring(`"jdbc.MaxLimit">20<`))
+		Expect(ords.Status.CurrentConfigRevision).To(Equal("ords-config-rev-1"))
+		Expect(ords.Status.ConfigRevisions).To(HaveLen(2))
+		Eventually(recorder.Events).Should(Receive(ContainSubstring("Configuration rolled back to revision ords-config-rev-1")))
+
+		ords.Spec.ConfigRevisions.RollbackTo = ""
+		reconcileConfig("40")
+		Expect(renderedMaxLimit()).To(ContainSubstring(`"jdbc.MaxLimit">40<`))
+		Expect(ords.Status.CurrentConfigRevision).To(Equal("ords-config-rev-2"))
+	})
+
+	It("should roll back by annotation", func() {
+		reconcileConfig("20")
+		reconcileConfig("40")
+		ords.Annotations = map[string]string{rollbackAnnotation: "ords-config-rev-1"}
+		reconcileConfig("40")
+		Expect(renderedMaxLimit()).To(ContainSubstring(`"jdbc.MaxLimit">20<`))
+	})
+
+	It("should reject rolling back to an unknown revision", func() {
+		ords.Spec.ConfigRevisions.RollbackTo = "ords-config-rev-9"
+		_, err := reconciler.ConfigRollbackLoad(ctx, ords)
+		Expect(err).To(MatchError(ContainSubstring("ords-config-rev-9")))
+	})
+})
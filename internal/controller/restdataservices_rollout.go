@@ -0,0 +1,168 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+/************************************************
+ * Rollout
+ *************************************************/
+// deploymentStrategyDefine returns the strategy of a Deployment; the Kubernetes default when not specified
+func deploymentStrategyDefine(rollout databasev1.RolloutSettings) appsv1.DeploymentStrategy {
+	if rollout.MaxSurge == nil && rollout.MaxUnavailable == nil {
+		return appsv1.DeploymentStrategy{}
+	}
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxSurge:       rollout.MaxSurge,
+			MaxUnavailable: rollout.MaxUnavailable,
+		},
+	}
+}
+
+// statefulSetStrategyDefine returns the update strategy of a StatefulSet; the Kubernetes default when not specified
+func statefulSetStrategyDefine(rollout databasev1.RolloutSettings) appsv1.StatefulSetUpdateStrategy {
+	if rollout.Partition == nil && rollout.MaxUnavailable == nil {
+		return appsv1.StatefulSetUpdateStrategy{}
+	}
+	return appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition:      rollout.Partition,
+			MaxUnavailable: rollout.MaxUnavailable,
+		},
+	}
+}
+
+// daemonSetStrategyDefine returns the update strategy of a DaemonSet; the Kubernetes default when not specified
+func daemonSetStrategyDefine(rollout databasev1.RolloutSettings) appsv1.DaemonSetUpdateStrategy {
+	if rollout.MaxSurge == nil && rollout.MaxUnavailable == nil {
+		return appsv1.DaemonSetUpdateStrategy{}
+	}
+	return appsv1.DaemonSetUpdateStrategy{
+		Type: appsv1.RollingUpdateDaemonSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+			MaxSurge:       rollout.MaxSurge,
+			MaxUnavailable: rollout.MaxUnavailable,
+		},
+	}
+}
+
+// rolloutCondition returns the Progressing condition of the Workload rollout, from the status of the Workload
+func rolloutCondition(workload client.Object) metav1.Condition {
+	var updated, desired, available int32
+	var observed bool
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		for _, condition := range w.Status.Conditions {
+			if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse &&
+				condition.Reason == "ProgressDeadlineExceeded" {
+				return metav1.Condition{Type: typeProgressingORDS, Status: metav1.ConditionFalse, Reason: "ProgressDeadlineExceeded",
+					Message: "Deployment rollout exceeded its progress deadline: " + condition.Message}
+			}
+		}
+		observed = w.Status.ObservedGeneration >= w.Generation
+		desired = 1
+		if w.Spec.Replicas != nil {
+			desired = *w.Spec.Replicas
+		}
+		updated, available = w.Status.UpdatedReplicas, w.Status.AvailableReplicas
+		// Pods of the previous revision are still terminating
+		if w.Status.Replicas > updated {
+			available = 0
+		}
+	case *appsv1.StatefulSet:
+		observed = w.Status.ObservedGeneration >= w.Generation
+		desired = 1
+		if w.Spec.Replicas != nil {
+			desired = *w.Spec.Replicas
+		}
+		updated, available = w.Status.UpdatedReplicas, w.Status.AvailableReplicas
+		if w.Status.UpdateRevision == w.Status.CurrentRevision {
+			// Every pod runs the current revision
+			updated = w.Status.Replicas
+		} else if rollingUpdate := w.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+			// Pods below the partition keep the previous revision
+			updated += min(*rollingUpdate.Partition, desired)
+		}
+	case *appsv1.DaemonSet:
+		observed = w.Status.ObservedGeneration >= w.Generation
+		desired = w.Status.DesiredNumberScheduled
+		updated, available = w.Status.UpdatedNumberScheduled, w.Status.NumberAvailable
+	default:
+		return metav1.Condition{Type: typeProgressingORDS, Status: metav1.ConditionUnknown, Reason: "Reconciling", Message: "Workload not found"}
+	}
+
+	if !observed {
+		return metav1.Condition{Type: typeProgressingORDS, Status: metav1.ConditionTrue, Reason: "RollingOut", Message: "Workload update pending"}
+	}
+	if updated < desired || available < desired {
+		return metav1.Condition{Type: typeProgressingORDS, Status: metav1.ConditionTrue, Reason: "RollingOut",
+			Message: fmt.Sprintf("%d of %d pods updated, %d available", min(updated, desired), desired, available)}
+	}
+	return metav1.Condition{Type: typeProgressingORDS, Status: metav1.ConditionFalse, Reason: "RolloutComplete",
+		Message: fmt.Sprintf("%d of %d pods updated and available", desired, desired)}
+}
+
+// rolloutEvent records an Event when the rollout completes or fails, from the previous Progressing condition
+func (r *RestDataServicesReconciler) rolloutEvent(ords *databasev1.RestDataServices, progressingCondition metav1.Condition) {
+	previous := meta.FindStatusCondition(ords.Status.Conditions, typeProgressingORDS)
+	if previous == nil || previous.Reason == progressingCondition.Reason {
+		return
+	}
+	switch progressingCondition.Reason {
+	case "ProgressDeadlineExceeded":
+		r.Recorder.Event(ords, corev1.EventTypeWarning, "RolloutFailed", progressingCondition.Message)
+	case "RolloutComplete":
+		if previous.Reason == "RollingOut" {
+			r.Recorder.Event(ords, corev1.EventTypeNormal, "RolloutComplete", progressingCondition.Message)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Rollout", func() {
+	ctx := context.Background()
+	var reconciler *RestDataServicesReconciler
+	var recorder *record.FakeRecorder
+	var ords *databasev1.RestDataServices
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+			Spec:       databasev1.RestDataServicesSpec{Image: "container-registry.oracle.com/database/ords:24.1.0"},
+		}
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+		recorder = reconciler.Recorder.(*record.FakeRecorder)
+	})
+
+	It("should keep the Kubernetes defaults when not specified", func() {
+		workload, _ := workloadDefine(ords, "Deployment", "hash-1", "hash-1")
+		Expect(workload.(*appsv1.Deployment).Spec.Strategy).To(Equal(appsv1.DeploymentStrategy{}))
+		Expect(workload.(*appsv1.Deployment).Spec.MinReadySeconds).To(BeZero())
+	})
+
+	It("should apply the rollout settings to each Workload type", func() {
+		maxSurge, maxUnavailable := intstr.FromInt32(1), intstr.FromInt32(0)
+		ords.Spec.Rollout = databasev1.RolloutSettings{
+			MaxSurge:                &maxSurge,
+			MaxUnavailable:          &maxUnavailable,
+			Partition:               &[]int32{1}[0],
+			MinReadySeconds:         30,
+			ProgressDeadlineSeconds: &[]int32{900}[0],
+			RevisionHistoryLimit:    &[]int32{3}[0],
+		}
+
+		workload, _ := workloadDefine(ords, "Deployment", "hash-1", "hash-1")
+		deployment := workload.(*appsv1.Deployment)
+		Expect(deployment.Spec.Strategy.RollingUpdate).To(Equal(&appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge, MaxUnavailable: &maxUnavailable}))
+		Expect(deployment.Spec.MinReadySeconds).To(Equal(int32(30)))
+		Expect(*deployment.Spec.ProgressDeadlineSeconds).To(Equal(int32(900)))
+		Expect(*deployment.Spec.RevisionHistoryLimit).To(Equal(int32(3)))
+
+		workload, _ = workloadDefine(ords, "StatefulSet", "hash-1", "hash-1")
+		statefulSet := workload.(*appsv1.StatefulSet)
+		Expect(statefulSet.Spec.UpdateStrategy.Type).To(Equal(appsv1.RollingUpdateStatefulSetStrategyType))
+		Expect(*statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(1)))
+		Expect(statefulSet.Spec.MinReadySeconds).To(Equal(int32(30)))
+
+		workload, _ = workloadDefine(ords, "DaemonSet", "hash-1", "hash-1")
+		daemonSet := workload.(*appsv1.DaemonSet)
+		Expect(daemonSet.Spec.UpdateStrategy.RollingUpdate.MaxSurge).To(Equal(&maxSurge))
+		Expect(*daemonSet.Spec.RevisionHistoryLimit).To(Equal(int32(3)))
+	})
+
+	DescribeTable("reporting the rollout progress",
+		func(workload *appsv1.Deployment, status metav1.ConditionStatus, reason string) {
+			condition := rolloutCondition(workload)
+			Expect(condition.Type).To(Equal(typeProgressingORDS))
+			Expect(condition.Status).To(Equal(status))
+			Expect(condition.Reason).To(Equal(reason))
+		},
+		Entry("complete", &appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: &[]int32{2}[0]},
+			Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+		}, metav1.ConditionFalse, "RolloutComplete"),
+		Entry("not yet observed", &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: &[]int32{2}[0]},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+		}, metav1.ConditionTrue, "RollingOut"),
+		Entry("replacing pods", &appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: &[]int32{2}[0]},
+			Status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 3},
+		}, metav1.ConditionTrue, "RollingOut"),
+		Entry("past its deadline", &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{Replicas: &[]int32{2}[0]},
+			Status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2, Conditions: []appsv1.DeploymentCondition{{
+				Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded",
+			}}},
+		}, metav1.ConditionFalse, "ProgressDeadlineExceeded"),
+	)
+
+	It("should report the StatefulSet rollout up to its partition", func() {
+		statefulSet := &appsv1.StatefulSet{
+			Spec: appsv1.StatefulSetSpec{Replicas: &[]int32{3}[0], UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &[]int32{2}[0]},
+			}},
+			Status: appsv1.StatefulSetStatus{Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 3, CurrentRevision: "r1", UpdateRevision: "r2"},
+		}
+		Expect(rolloutCondition(statefulSet).Reason).To(Equal("RolloutComplete"))
+		statefulSet.Status.UpdatedReplicas = 0
+		Expect(rolloutCondition(statefulSet).Reason).To(Equal("RollingOut"))
+	})
+
+	It("should set the Progressing condition and record the rollout completion", func() {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}}
+		condition := metav1.Condition{Type: typeAvailableORDS, Status: metav1.ConditionTrue, Reason: "Available", Message: "Workload in Sync"}
+		_, err := reconciler.WorkloadReconcile(ctx, req, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reconciler.SetStatus(ctx, req, ords, condition)).To(Succeed())
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typeProgressingORDS).Reason).To(Equal("RollingOut"))
+
+		workload := &appsv1.Deployment{}
+		Expect(reconciler.Get(ctx, req.NamespacedName, workload)).To(Succeed())
+		workload.Status = appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1, ReadyReplicas: 1}
+		Expect(reconciler.Status().Update(ctx, workload)).To(Succeed())
+		Expect(reconciler.SetStatus(ctx, req, ords, condition)).To(Succeed())
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typeProgressingORDS).Reason).To(Equal("RolloutComplete"))
+		Eventually(recorder.Events).Should(Receive(ContainSubstring("RolloutComplete")))
+	})
+})
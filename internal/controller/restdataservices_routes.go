@@ -0,0 +1,228 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+// HTTPRoutes are managed as unstructured objects; the Gateway API CRDs are optional in the cluster
+var httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// The header set by Ingress controllers and Gateways terminating TLS
+const forwardedProtoHeaderCheck = "X-Forwarded-Proto: https"
+
+/************************************************
+ * Ingress
+ *************************************************/
+// IngressReconcile creates or updates the Ingress of spec.ingress, deleting it once no longer specified
+func (r *RestDataServicesReconciler) IngressReconcile(ctx context.Context, ords *databasev1.RestDataServices) error {
+	definedIngress := &networkingv1.Ingress{}
+	var desiredIngress client.Object
+	var desiredSpecHash string
+	if ords.Spec.Ingress != nil {
+		desiredIngress, desiredSpecHash = ingressDefine(ords)
+	}
+	return r.ownedReconcile(ctx, ords, "Ingress", definedIngress, desiredIngress, desiredSpecHash)
+}
+
+func ingressDefine(ords *databasev1.RestDataServices) (*networkingv1.Ingress, string) {
+	ingressSettings := ords.Spec.Ingress
+	host := ingressSettings.Host
+	if host == "" {
+		host = ords.Spec.GlobalSettings.StandaloneHTTPSHost
+	}
+	pathType := networkingv1.PathTypePrefix
+	spec := networkingv1.IngressSpec{
+		IngressClassName: ingressSettings.IngressClassName,
+		Rules: []networkingv1.IngressRule{{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{{
+					Path:     contextPath(ords),
+					PathType: &pathType,
+					Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+						Name: ords.Name,
+						Port: networkingv1.ServiceBackendPort{Name: serviceHTTPPortName},
+					}},
+				}},
+			}},
+		}},
+	}
+	if tlsSecretName := ingressTLSSecretName(ords); tlsSecretName != "" {
+		tls := networkingv1.IngressTLS{SecretName: tlsSecretName}
+		if host != "" {
+			tls.Hosts = []string{host}
+		}
+		spec.TLS = []networkingv1.IngressTLS{tls}
+	}
+
+	objectMeta := routeMetaDefine(ords, ingressSettings.Labels, ingressSettings.Annotations)
+	specHash := generateSpecHash([]interface{}{objectMeta, spec})
+	objectMeta.Labels[specHashLabel] = specHash
+	return &networkingv1.Ingress{ObjectMeta: objectMeta, Spec: spec}, specHash
+}
+
+// ingressTLSSecretName returns the TLS Secret of the Ingress; the certSecret when it has the keys of a TLS Secret
+func ingressTLSSecretName(ords *databasev1.RestDataServices) string {
+	if ords.Spec.Ingress.TLSSecretName != "" {
+		return ords.Spec.Ingress.TLSSecretName
+	}
+	certSecret := ords.Spec.GlobalSettings.CertSecret
+	if certSecret != nil && certSecret.Certificate == corev1.TLSCertKey && certSecret.CertificateKey == corev1.TLSPrivateKeyKey {
+		return certSecret.SecretName
+	}
+	return ""
+}
+
+/************************************************
+ * HTTPRoute
+ *************************************************/
+// HTTPRouteReconcile creates or updates the Gateway API HTTPRoute of spec.httpRoute, deleting it once no longer specified
+func (r *RestDataServicesReconciler) HTTPRouteReconcile(ctx context.Context, ords *databasev1.RestDataServices) error {
+	definedHTTPRoute := &unstructured.Unstructured{}
+	definedHTTPRoute.SetGroupVersionKind(httpRouteGVK)
+	var desiredHTTPRoute client.Object
+	var desiredSpecHash string
+	if ords.Spec.HTTPRoute != nil {
+		desiredHTTPRoute, desiredSpecHash = httpRouteDefine(ords)
+	}
+	err := r.ownedReconcile(ctx, ords, "HTTPRoute", definedHTTPRoute, desiredHTTPRoute, desiredSpecHash)
+	if meta.IsNoMatchError(err) {
+		if ords.Spec.HTTPRoute == nil {
+			return nil
+		}
+		return fmt.Errorf("httpRoute requires the Gateway API CRDs to be installed: %w", err)
+	}
+	return err
+}
+
+func httpRouteDefine(ords *databasev1.RestDataServices) (*unstructured.Unstructured, string) {
+	httpRouteSettings := ords.Spec.HTTPRoute
+	parentRefs := make([]interface{}, 0, len(httpRouteSettings.ParentRefs))
+	for _, parentRef := range httpRouteSettings.ParentRefs {
+		ref := map[string]interface{}{"name": parentRef.Name}
+		if parentRef.Namespace != "" {
+			ref["namespace"] = parentRef.Namespace
+		}
+		if parentRef.SectionName != "" {
+			ref["sectionName"] = parentRef.SectionName
+		}
+		parentRefs = append(parentRefs, ref)
+	}
+	spec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules": []interface{}{map[string]interface{}{
+			"matches": []interface{}{map[string]interface{}{
+				"path": map[string]interface{}{"type": "PathPrefix", "value": contextPath(ords)},
+			}},
+			"backendRefs": []interface{}{map[string]interface{}{
+				"name": ords.Name,
+				"port": int64(*ords.Spec.GlobalSettings.StandaloneHTTPPort),
+			}},
+		}},
+	}
+	hostnames := httpRouteSettings.Hostnames
+	if len(hostnames) == 0 && ords.Spec.GlobalSettings.StandaloneHTTPSHost != "" {
+		hostnames = []string{ords.Spec.GlobalSettings.StandaloneHTTPSHost}
+	}
+	if len(hostnames) > 0 {
+		values := make([]interface{}, 0, len(hostnames))
+		for _, hostname := range hostnames {
+			values = append(values, hostname)
+		}
+		spec["hostnames"] = values
+	}
+
+	objectMeta := routeMetaDefine(ords, httpRouteSettings.Labels, httpRouteSettings.Annotations)
+	specHash := generateSpecHash([]interface{}{objectMeta, spec})
+	objectMeta.Labels[specHashLabel] = specHash
+
+	httpRoute := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	httpRoute.SetGroupVersionKind(httpRouteGVK)
+	httpRoute.SetName(objectMeta.Name)
+	httpRoute.SetNamespace(objectMeta.Namespace)
+	httpRoute.SetLabels(objectMeta.Labels)
+	httpRoute.SetAnnotations(objectMeta.Annotations)
+	return httpRoute, specHash
+}
+
+// routeMetaDefine returns the metadata of a route; the operator's labels take precedence
+func routeMetaDefine(ords *databasev1.RestDataServices, labels map[string]string, annotations map[string]string) metav1.ObjectMeta {
+	objectMeta := objectMetaDefine(ords, ords.Name)
+	objectMeta.Labels = make(map[string]string)
+	for key, value := range labels {
+		objectMeta.Labels[key] = value
+	}
+	for key, value := range getLabels(ords.Name) {
+		objectMeta.Labels[key] = value
+	}
+	objectMeta.Annotations = annotations
+	return objectMeta
+}
+
+// contextPath returns the standalone.context.path routed to the Service, without a trailing /
+func contextPath(ords *databasev1.RestDataServices) string {
+	path := strings.TrimSuffix(ords.Spec.GlobalSettings.StandaloneContextPath, "/")
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// httpsHeaderCheck returns the security.httpsHeaderCheck; when routed by an Ingress or HTTPRoute, the header
+// set when terminating TLS so that ORDS knows the original request used HTTPS
+func httpsHeaderCheck(ords *databasev1.RestDataServices) string {
+	if ords.Spec.GlobalSettings.SecurityHTTPSHeaderCheck == "" && (ords.Spec.Ingress != nil || ords.Spec.HTTPRoute != nil) {
+		return forwardedProtoHeaderCheck
+	}
+	return ords.Spec.GlobalSettings.SecurityHTTPSHeaderCheck
+}
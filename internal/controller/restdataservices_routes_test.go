@@ -0,0 +1,214 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Routes", func() {
+	ctx := context.Background()
+	var reconciler *RestDataServicesReconciler
+	var ords *databasev1.RestDataServices
+
+	ingress := func() *networkingv1.Ingress {
+		definedIngress := &networkingv1.Ingress{}
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, definedIngress)).To(Succeed())
+		return definedIngress
+	}
+
+	httpRoute := func() *unstructured.Unstructured {
+		definedHTTPRoute := &unstructured.Unstructured{}
+		definedHTTPRoute.SetGroupVersionKind(httpRouteGVK)
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, definedHTTPRoute)).To(Succeed())
+		return definedHTTPRoute
+	}
+
+	intercept := func(funcs interceptor.Funcs) {
+		reconciler.Client = interceptor.NewClient(reconciler.Client.(client.WithWatch), funcs)
+	}
+
+	// Reject the HTTPRoutes as an API server without the Gateway API CRDs
+	withoutGatewayAPI := interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if obj.GetObjectKind().GroupVersionKind() == httpRouteGVK {
+				return &meta.NoKindMatchError{GroupKind: httpRouteGVK.GroupKind(), SearchedVersions: []string{httpRouteGVK.Version}}
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+			Spec:       databasev1.RestDataServicesSpec{Image: "container-registry.oracle.com/database/ords:24.1.0"},
+		}
+		ords.Spec.GlobalSettings.StandaloneHTTPSHost = "ords.example.com"
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+	})
+
+	Context("Ingress", func() {
+		It("should not create an Ingress when not specified", func() {
+			Expect(reconciler.IngressReconcile(ctx, ords)).To(Succeed())
+			err := reconciler.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, &networkingv1.Ingress{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should route the context path of the host to the Service", func() {
+			className := "nginx"
+			ords.Spec.Ingress = &databasev1.IngressSettings{
+				IngressClassName: &className,
+				Annotations:      map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "10m"},
+			}
+			Expect(reconciler.IngressReconcile(ctx, ords)).To(Succeed())
+
+			definedIngress := ingress()
+			Expect(metav1.IsControlledBy(definedIngress, ords)).To(BeTrue())
+			Expect(definedIngress.Spec.IngressClassName).To(Equal(&className))
+			Expect(definedIngress.Annotations).To(HaveKeyWithValue("nginx.ingress.kubernetes.io/proxy-body-size", "10m"))
+			Expect(definedIngress.Spec.Rules).To(HaveLen(1))
+			Expect(definedIngress.Spec.Rules[0].Host).To(Equal("ords.example.com"))
+			path := definedIngress.Spec.Rules[0].HTTP.Paths[0]
+			Expect(path.Path).To(Equal("/ords"))
+			Expect(*path.PathType).To(Equal(networkingv1.PathTypePrefix))
+			Expect(path.Backend.Service.Name).To(Equal(ords.Name))
+			Expect(path.Backend.Service.Port.Name).To(Equal(serviceHTTPPortName))
+			Expect(definedIngress.Spec.TLS).To(BeEmpty())
+		})
+
+		It("should use the certSecret for TLS when it is a TLS Secret", func() {
+			ords.Spec.GlobalSettings.CertSecret = &databasev1.CertificateSecret{
+				SecretName: "ords-tls", Certificate: corev1.TLSCertKey, CertificateKey: corev1.TLSPrivateKeyKey,
+			}
+			ords.Spec.Ingress = &databasev1.IngressSettings{Host: "apis.example.com"}
+			Expect(reconciler.IngressReconcile(ctx, ords)).To(Succeed())
+			Expect(ingress().Spec.TLS).To(Equal([]networkingv1.IngressTLS{{Hosts: []string{"apis.example.com"}, SecretName: "ords-tls"}}))
+
+			ords.Spec.GlobalSettings.CertSecret.Certificate = "ords.crt"
+			Expect(reconciler.IngressReconcile(ctx, ords)).To(Succeed())
+			Expect(ingress().Spec.TLS).To(BeEmpty())
+
+			ords.Spec.Ingress.TLSSecretName = "apis-tls"
+			Expect(reconciler.IngressReconcile(ctx, ords)).To(Succeed())
+			Expect(ingress().Spec.TLS[0].SecretName).To(Equal("apis-tls"))
+		})
+
+		It("should delete the Ingress once no longer specified", func() {
+			ords.Spec.Ingress = &databasev1.IngressSettings{}
+			Expect(reconciler.IngressReconcile(ctx, ords)).To(Succeed())
+			ingress()
+
+			ords.Spec.Ingress = nil
+			Expect(reconciler.IngressReconcile(ctx, ords)).To(Succeed())
+			err := reconciler.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, &networkingv1.Ingress{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("HTTPRoute", func() {
+		It("should ignore a missing Gateway API when not specified", func() {
+			intercept(withoutGatewayAPI)
+			Expect(reconciler.HTTPRouteReconcile(ctx, ords)).To(Succeed())
+		})
+
+		It("should return an error when specified without the Gateway API", func() {
+			intercept(withoutGatewayAPI)
+			ords.Spec.HTTPRoute = &databasev1.HTTPRouteSettings{ParentRefs: []databasev1.HTTPRouteParentRef{{Name: "gateway"}}}
+			Expect(reconciler.HTTPRouteReconcile(ctx, ords)).To(MatchError(ContainSubstring("Gateway API")))
+		})
+
+		It("should route the context path of the hostnames to the Service", func() {
+			ords.Spec.HTTPRoute = &databasev1.HTTPRouteSettings{
+				ParentRefs: []databasev1.HTTPRouteParentRef{{Name: "gateway", Namespace: "infra", SectionName: "https"}},
+			}
+			Expect(reconciler.HTTPRouteReconcile(ctx, ords)).To(Succeed())
+
+			definedHTTPRoute := httpRoute()
+			Expect(metav1.IsControlledBy(definedHTTPRoute, ords)).To(BeTrue())
+			parentRefs, _, _ := unstructured.NestedSlice(definedHTTPRoute.Object, "spec", "parentRefs")
+			Expect(parentRefs).To(Equal([]interface{}{map[string]interface{}{"name": "gateway", "namespace": "infra", "sectionName": "https"}}))
+			hostnames, _, _ := unstructured.NestedStringSlice(definedHTTPRoute.Object, "spec", "hostnames")
+			Expect(hostnames).To(Equal([]string{"ords.example.com"}))
+			rules, _, _ := unstructured.NestedSlice(definedHTTPRoute.Object, "spec", "rules")
+			Expect(rules).To(HaveLen(1))
+			rule := rules[0].(map[string]interface{})
+			Expect(rule["matches"]).To(Equal([]interface{}{map[string]interface{}{
+				"path": map[string]interface{}{"type": "PathPrefix", "value": "/ords"},
+			}}))
+			Expect(rule["backendRefs"]).To(Equal([]interface{}{map[string]interface{}{"name": ords.Name, "port": int64(8080)}}))
+
+			ords.Spec.HTTPRoute.Hostnames = []string{"apis.example.com"}
+			Expect(reconciler.HTTPRouteReconcile(ctx, ords)).To(Succeed())
+			hostnames, _, _ = unstructured.NestedStringSlice(httpRoute().Object, "spec", "hostnames")
+			Expect(hostnames).To(Equal([]string{"apis.example.com"}))
+
+			ords.Spec.HTTPRoute = nil
+			Expect(reconciler.HTTPRouteReconcile(ctx, ords)).To(Succeed())
+			definedHTTPRoute = &unstructured.Unstructured{}
+			definedHTTPRoute.SetGroupVersionKind(httpRouteGVK)
+			err := reconciler.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, definedHTTPRoute)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("HTTPS header check", func() {
+		It("should default to X-Forwarded-Proto when routed", func() {
+			Expect(httpsHeaderCheck(ords)).To(BeEmpty())
+			ords.Spec.Ingress = &databasev1.IngressSettings{}
+			Expect(httpsHeaderCheck(ords)).To(Equal("X-Forwarded-Proto: https"))
+			ords.Spec.GlobalSettings.SecurityHTTPSHeaderCheck = "X-Forwarded-Ssl: on"
+			Expect(httpsHeaderCheck(ords)).To(Equal("X-Forwarded-Ssl: on"))
+		})
+	})
+})
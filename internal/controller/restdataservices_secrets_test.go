@@ -0,0 +1,120 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Referenced Secrets", func() {
+	ctx := context.Background()
+	var reconciler *RestDataServicesReconciler
+	var ords *databasev1.RestDataServices
+	var secret *corev1.Secret
+
+	secretsHash := func() string {
+		renderedConfig := make(map[string]map[string]string)
+		Expect(reconciler.SecretsReconcile(ctx, ords, renderedConfig)).To(Succeed())
+		return generateSpecHash(renderedConfig)
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default"},
+			Spec: databasev1.RestDataServicesSpec{
+				PoolSettings: []*databasev1.PoolSettings{{
+					PoolName:       "default",
+					DBSecret:       databasev1.PasswordSecret{SecretName: "db-auth"},
+					TNSAdminSecret: &databasev1.TNSAdminSecret{SecretName: "tns-admin"},
+				}},
+			},
+		}
+		ords.Spec.SetDefaults()
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-auth", Namespace: "default"},
+			Data:       map[string][]byte{"password": []byte("first"), "unused": []byte("value")},
+		}
+		reconciler = newFakeReconciler(ords, secret)
+	})
+
+	It("should list the referenced Secrets with the keys used", func() {
+		Expect(referencedSecrets(ords)).To(Equal(map[string]map[string]bool{
+			"db-auth":   {"password": true},
+			"tns-admin": nil,
+		}))
+	})
+
+	It("should change the hash when a referenced key is rotated", func() {
+		initial := secretsHash()
+
+		secret.Data["unused"] = []byte("changed")
+		Expect(reconciler.Update(ctx, secret)).To(Succeed())
+		Expect(secretsHash()).To(Equal(initial))
+
+		secret.Data["password"] = []byte("second")
+		Expect(reconciler.Update(ctx, secret)).To(Succeed())
+		Expect(secretsHash()).NotTo(Equal(initial))
+	})
+
+	It("should change the hash when a missing Secret is created", func() {
+		initial := secretsHash()
+		Expect(reconciler.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tns-admin", Namespace: "default"},
+			Data:       map[string][]byte{"tnsnames.ora": []byte("FREEPDB1=")},
+		})).To(Succeed())
+		Expect(secretsHash()).NotTo(Equal(initial))
+	})
+
+	It("should map a Secret to the resources referencing it", func() {
+		Expect(reconciler.secretToRequests(ctx, secret)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "ords", Namespace: "default"}}))
+		Expect(reconciler.secretToRequests(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,153 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Service", func() {
+	ctx := context.Background()
+	var reconciler *RestDataServicesReconciler
+	var ords *databasev1.RestDataServices
+
+	service := func() *corev1.Service {
+		definedService := &corev1.Service{}
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, definedService)).To(Succeed())
+		return definedService
+	}
+
+	reconcileService := func() {
+		Expect(reconciler.ServiceReconcile(ctx, ords)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+			Spec:       databasev1.RestDataServicesSpec{Image: "container-registry.oracle.com/database/ords:24.1.0"},
+		}
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+	})
+
+	It("should create a ClusterIP Service by default", func() {
+		reconcileService()
+		Expect(service().Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+		Expect(service().Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityNone))
+		Expect(service().Spec.ExternalTrafficPolicy).To(BeEmpty())
+		Expect(service().Spec.Selector).To(Equal(getLabels(ords.Name)))
+	})
+
+	It("should create a LoadBalancer Service with the service settings", func() {
+		ords.Spec.Service = databasev1.ServiceSettings{
+			Type:                     corev1.ServiceTypeLoadBalancer,
+			Annotations:              map[string]string{"service.beta.kubernetes.io/oci-load-balancer-shape": "flexible"},
+			Labels:                   map[string]string{"team": "apis", controllerLabelKey: "other"},
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+			SessionAffinity:          corev1.ServiceAffinityClientIP,
+			NodePorts:                databasev1.ServiceNodePorts{HTTPS: 30443},
+		}
+		reconcileService()
+
+		definedService := service()
+		Expect(definedService.Annotations).To(HaveKeyWithValue("service.beta.kubernetes.io/oci-load-balancer-shape", "flexible"))
+		Expect(definedService.Labels).To(HaveKeyWithValue("team", "apis"))
+		Expect(definedService.Labels).To(HaveKeyWithValue(controllerLabelKey, controllerLabelVal))
+		Expect(definedService.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+		Expect(definedService.Spec.LoadBalancerSourceRanges).To(Equal([]string{"10.0.0.0/8"}))
+		Expect(definedService.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyLocal))
+		Expect(definedService.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+		Expect(definedService.Spec.Ports[1].NodePort).To(Equal(int32(30443)))
+	})
+
+	It("should keep the node ports allocated by Kubernetes", func() {
+		ords.Spec.Service.Type = corev1.ServiceTypeNodePort
+		reconcileService()
+		definedService := service()
+		definedService.Spec.Ports[0].NodePort = 31080
+		Expect(reconciler.Update(ctx, definedService)).To(Succeed())
+
+		reconcileService()
+		Expect(service().Spec.Ports[0].NodePort).To(Equal(int32(31080)))
+		Expect(service().ResourceVersion).To(Equal(definedService.ResourceVersion))
+	})
+
+	It("should revert drift of the Service while keeping metadata set by others", func() {
+		ords.Spec.Service.Annotations = map[string]string{"example.com/managed": "true"}
+		reconcileService()
+		definedService := service()
+		definedService.Spec.Type = corev1.ServiceTypeNodePort
+		definedService.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+		definedService.Annotations["example.com/managed"] = "false"
+		definedService.Annotations["cloud.example.com/status"] = "provisioned"
+		Expect(reconciler.Update(ctx, definedService)).To(Succeed())
+
+		reconcileService()
+		definedService = service()
+		Expect(definedService.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+		Expect(definedService.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityNone))
+		Expect(definedService.Annotations).To(HaveKeyWithValue("example.com/managed", "true"))
+		Expect(definedService.Annotations).To(HaveKeyWithValue("cloud.example.com/status", "provisioned"))
+	})
+
+	It("should remove the annotations no longer specified", func() {
+		ords.Spec.Service.Annotations = map[string]string{"example.com/managed": "true"}
+		reconcileService()
+
+		ords.Spec.Service.Annotations = nil
+		reconcileService()
+		Expect(service().Annotations).NotTo(HaveKey("example.com/managed"))
+	})
+
+	It("should update the ports when the Mongo API is enabled", func() {
+		reconcileService()
+		ords.Spec.GlobalSettings.MongoEnabled = true
+		reconcileService()
+		Expect(service().Spec.Ports).To(HaveLen(3))
+	})
+})
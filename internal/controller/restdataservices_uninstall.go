@@ -0,0 +1,235 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+/************************************************
+ * Finalizer
+ *************************************************/
+// FinalizeReconcile uninstalls the pools with an Uninstall deletionPolicy from the database
+// before releasing the finalizer of a deleted resource
+func (r *RestDataServicesReconciler) FinalizeReconcile(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices) (ctrl.Result, error) {
+	logr := log.FromContext(ctx).WithName("FinalizeReconcile")
+	if !controllerutil.ContainsFinalizer(ords, ordsFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	// Stop the Workloads, of every kind in case of a migration, and the install Jobs so that
+	// the database users are no longer in use
+	for _, kind := range []string{"Deployment", "StatefulSet", "DaemonSet"} {
+		workload := workloadObject(kind)
+		workload.SetName(ords.Name)
+		workload.SetNamespace(ords.Namespace)
+		if err := r.Delete(ctx, workload); err == nil {
+			logr.Info("Deleted: " + kind)
+			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Workload %s Deleted", kind)
+		} else if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	}
+	jobList := &batchv1.JobList{}
+	if err := r.List(ctx, jobList, client.InNamespace(req.Namespace),
+		client.MatchingLabels(map[string]string{
+			controllerLabelKey:           controllerLabelVal,
+			"app.kubernetes.io/instance": ords.Name}),
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if !strings.HasPrefix(job.Name, ords.Name+"-install-") {
+			continue
+		}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		logr.Info("Deleted: " + job.Name)
+		r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Delete", "Job %s Deleted", job.Name)
+	}
+
+	uninstalled, err := r.UninstallReconcile(ctx, req, ords, true)
+	if err != nil {
+		logr.Error(err, "Error in UninstallReconcile")
+		return ctrl.Result{}, err
+	}
+	if !uninstalled {
+		// Reconciled again when the uninstall Jobs change
+		return ctrl.Result{}, nil
+	}
+	if err := r.FinalizerReconcile(ctx, ords, false); err != nil {
+		logr.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+	logr.Info("Released finalizer")
+	return ctrl.Result{}, nil
+}
+
+// FinalizerReconcile adds or removes the finalizer; only the finalizers are patched so the
+// defaulted spec is not written back
+func (r *RestDataServicesReconciler) FinalizerReconcile(ctx context.Context, ords *databasev1.RestDataServices, required bool) error {
+	patch := client.MergeFrom(ords.DeepCopy())
+	var changed bool
+	if required {
+		changed = controllerutil.AddFinalizer(ords, ordsFinalizer)
+	} else {
+		changed = controllerutil.RemoveFinalizer(ords, ordsFinalizer)
+	}
+	if !changed {
+		return nil
+	}
+	return r.Patch(ctx, ords, patch)
+}
+
+// uninstallRequired returns true when a pool is to be uninstalled on deletion
+func uninstallRequired(ords *databasev1.RestDataServices) bool {
+	for _, pool := range ords.Spec.PoolSettings {
+		if pool.DeletionPolicy == databasev1.DeletionPolicyUninstall {
+			return true
+		}
+	}
+	return false
+}
+
+/************************************************
+ * Uninstall
+ *************************************************/
+// UninstallReconcile runs an uninstall Job for each removed pool, or each pool when the resource is deleting,
+// whose ConfigMap carries the uninstall annotation.  The pool ConfigMap and Job are deleted once the pool is
+// uninstalled; it returns true when no pool remains to be uninstalled.
+func (r *RestDataServicesReconciler) UninstallReconcile(ctx context.Context, req ctrl.Request, ords *databasev1.RestDataServices, deleting bool) (uninstalled bool, err error) {
+	logr := log.FromContext(ctx).WithName("UninstallReconcile")
+
+	definedPools := make(map[string]bool)
+	if !deleting {
+		for _, pool := range ords.Spec.PoolSettings {
+			definedPools[ords.Name+"-"+poolConfigPreName+strings.ToLower(pool.PoolName)] = true
+		}
+	}
+
+	configMapList := &corev1.ConfigMapList{}
+	if err := r.List(ctx, configMapList, client.InNamespace(req.Namespace),
+		client.MatchingLabels(map[string]string{
+			controllerLabelKey:           controllerLabelVal,
+			"app.kubernetes.io/instance": ords.Name}),
+	); err != nil {
+		return false, err
+	}
+
+	var running, failed, completed []string
+	for _, configMap := range configMapList.Items {
+		poolSettings, exists := configMap.Annotations[uninstallAnnotation]
+		if !exists || definedPools[configMap.Name] {
+			continue
+		}
+		pool := &databasev1.PoolSettings{}
+		if err := json.Unmarshal([]byte(poolSettings), pool); err != nil {
+			return false, err
+		}
+		poolName := strings.ToLower(pool.PoolName)
+
+		job, err := r.PoolJobReconcile(ctx, ords, pool, "uninstall")
+		if err != nil {
+			return false, err
+		}
+		switch jobState(job) {
+		case batchv1.JobComplete:
+			if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return false, err
+			}
+			if err := r.Delete(ctx, &configMap); client.IgnoreNotFound(err) != nil {
+				return false, err
+			}
+			logr.Info("Uninstalled: " + poolName)
+			r.Recorder.Eventf(ords, corev1.EventTypeNormal, "Uninstalled", "Pool %s Uninstalled", poolName)
+			completed = append(completed, poolName)
+		case batchv1.JobFailed:
+			message := fmt.Sprintf("Job %s Failed", job.Name)
+			jobResults, err := r.jobResults(ctx, job)
+			if err != nil {
+				return false, err
+			}
+			for _, result := range jobResults {
+				if result.Pool == poolName && result.Error != "" {
+					message += ": " + result.Error
+				}
+			}
+			r.Recorder.Event(ords, corev1.EventTypeWarning, "UninstallFailed", message)
+			failed = append(failed, poolName)
+		default:
+			running = append(running, poolName)
+		}
+	}
+
+	var condition metav1.Condition
+	switch {
+	case len(failed) > 0:
+		condition = metav1.Condition{Type: typeUninstallingORDS, Status: metav1.ConditionFalse, Reason: "Failed",
+			Message: fmt.Sprintf("Failed to uninstall pools %s; delete the Job to retry or the pool ConfigMap to retain the database",
+				strings.Join(failed, ", "))}
+	case len(running) > 0:
+		condition = metav1.Condition{Type: typeUninstallingORDS, Status: metav1.ConditionTrue, Reason: "Running",
+			Message: "Uninstalling pools " + strings.Join(running, ", ")}
+	case len(completed) > 0:
+		condition = metav1.Condition{Type: typeUninstallingORDS, Status: metav1.ConditionFalse, Reason: "Completed",
+			Message: "Uninstalled pools " + strings.Join(completed, ", ")}
+	default:
+		return true, nil
+	}
+	if err := r.SetStatus(ctx, req, ords, condition); err != nil {
+		return false, err
+	}
+	return len(running) == 0 && len(failed) == 0, nil
+}
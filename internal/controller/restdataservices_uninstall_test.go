@@ -0,0 +1,206 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Pool uninstall", func() {
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "ords", Namespace: "default"}}
+	poolConfigMapName := "ords-" + poolConfigPreName + "default"
+	var reconciler *RestDataServicesReconciler
+	var ords *databasev1.RestDataServices
+
+	uninstallJob := func() *batchv1.Job {
+		job := &batchv1.Job{}
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: "ords-uninstall-default", Namespace: "default"}, job)).To(Succeed())
+		return job
+	}
+
+	finishJob := func(conditionType batchv1.JobConditionType) {
+		job := uninstallJob()
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		Expect(reconciler.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	poolConfigMapExists := func() bool {
+		err := reconciler.Get(ctx, types.NamespacedName{Name: poolConfigMapName, Namespace: "default"}, &corev1.ConfigMap{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid", Finalizers: []string{ordsFinalizer}},
+			Spec: databasev1.RestDataServicesSpec{
+				Image: "container-registry.oracle.com/database/ords:24.1.0",
+				PoolSettings: []*databasev1.PoolSettings{{
+					PoolName:          "default",
+					DeletionPolicy:    databasev1.DeletionPolicyUninstall,
+					DBAdminUser:       "SYS",
+					DBSecret:          databasev1.PasswordSecret{SecretName: "db-auth"},
+					DBAdminUserSecret: databasev1.PasswordSecret{SecretName: "db-admin-auth"},
+				}},
+			},
+		}
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+		Expect(reconciler.ConfigMapReconcile(ctx, ords, poolConfigMapName, 0, nil, map[string]map[string]string{})).To(Succeed())
+	})
+
+	It("should keep the pool settings with the pool ConfigMap", func() {
+		configMap := &corev1.ConfigMap{}
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: poolConfigMapName, Namespace: "default"}, configMap)).To(Succeed())
+		Expect(configMap.Annotations).To(HaveKey(uninstallAnnotation))
+
+		ords.Spec.PoolSettings[0].DeletionPolicy = databasev1.DeletionPolicyRetain
+		Expect(reconciler.ConfigMapReconcile(ctx, ords, poolConfigMapName, 0, nil, map[string]map[string]string{})).To(Succeed())
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: poolConfigMapName, Namespace: "default"}, configMap)).To(Succeed())
+		Expect(configMap.Annotations).NotTo(HaveKey(uninstallAnnotation))
+	})
+
+	It("should not uninstall pools that are defined", func() {
+		uninstalled, err := reconciler.UninstallReconcile(ctx, req, ords, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uninstalled).To(BeTrue())
+		Expect(poolConfigMapExists()).To(BeTrue())
+	})
+
+	It("should uninstall a removed pool before deleting its ConfigMap", func() {
+		ords.Spec.PoolSettings = nil
+		uninstalled, err := reconciler.UninstallReconcile(ctx, req, ords, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uninstalled).To(BeFalse())
+		Expect(uninstallJob().Spec.Template.Spec.Containers[0].Command).To(ContainElement(ContainSubstring("uninstall default")))
+		Expect(uninstallJob().Spec.Template.Labels).NotTo(HaveKey(controllerLabelKey))
+		Expect(poolConfigMapExists()).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(ords.Status.Conditions, typeUninstallingORDS)).To(BeTrue())
+
+		finishJob(batchv1.JobComplete)
+		ords.Spec.PoolSettings = nil
+		uninstalled, err = reconciler.UninstallReconcile(ctx, req, ords, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uninstalled).To(BeTrue())
+		Expect(poolConfigMapExists()).To(BeFalse())
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typeUninstallingORDS).Reason).To(Equal("Completed"))
+	})
+
+	It("should report a failed uninstall and keep the pool ConfigMap", func() {
+		ords.Spec.PoolSettings = nil
+		_, err := reconciler.UninstallReconcile(ctx, req, ords, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(reconciler.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords-uninstall-default-abcde", Namespace: "default",
+				Labels: map[string]string{"job-name": "ords-uninstall-default"}},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "uninstall",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Message: `{"ordsVersion":"24.1.0.r1234","pools":[{"pool":"default","exitCode":1,"result":"Failed",` +
+						`"action":"uninstall","dbType":"","ordsVersion":"","apexVersion":"","connected":false,` +
+						`"error":"ORA-01017: invalid username/password"}]}`,
+					FinishedAt: metav1.Now()}},
+			}}},
+		})).To(Succeed())
+		finishJob(batchv1.JobFailed)
+		ords.Spec.PoolSettings = nil
+		uninstalled, err := reconciler.UninstallReconcile(ctx, req, ords, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uninstalled).To(BeFalse())
+		Expect(poolConfigMapExists()).To(BeTrue())
+		Expect(meta.FindStatusCondition(ords.Status.Conditions, typeUninstallingORDS).Reason).To(Equal("Failed"))
+		Eventually(reconciler.Recorder.(*record.FakeRecorder).Events).Should(Receive(ContainSubstring("ORA-01017")))
+	})
+
+	It("should release the finalizer once the pools are uninstalled", func() {
+		Expect(reconciler.Delete(ctx, ords)).To(Succeed())
+		Expect(reconciler.getDefaulted(ctx, req.NamespacedName, ords)).To(Succeed())
+
+		_, err := reconciler.FinalizeReconcile(ctx, req, ords)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reconciler.getDefaulted(ctx, req.NamespacedName, ords)).To(Succeed())
+		Expect(ords.Finalizers).To(ContainElement(ordsFinalizer))
+		Expect(ords.Status.Status).To(Equal("Deleting"))
+
+		finishJob(batchv1.JobComplete)
+		_, err = reconciler.FinalizeReconcile(ctx, req, ords)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apierrors.IsNotFound(reconciler.Get(ctx, req.NamespacedName, ords))).To(BeTrue())
+	})
+
+	It("should stop the Workloads of every kind and the install Jobs before uninstalling", func() {
+		for _, kind := range []string{"Deployment", "DaemonSet"} {
+			workload := workloadObject(kind)
+			workload.SetName("ords")
+			workload.SetNamespace("default")
+			Expect(reconciler.Create(ctx, workload)).To(Succeed())
+		}
+		installJob, _ := poolJobDefine(ords, ords.Spec.PoolSettings[0], "install")
+		Expect(reconciler.Create(ctx, installJob)).To(Succeed())
+		Expect(reconciler.Delete(ctx, ords)).To(Succeed())
+		Expect(reconciler.getDefaulted(ctx, req.NamespacedName, ords)).To(Succeed())
+
+		_, err := reconciler.FinalizeReconcile(ctx, req, ords)
+		Expect(err).NotTo(HaveOccurred())
+		for _, kind := range []string{"Deployment", "DaemonSet"} {
+			workload := workloadObject(kind)
+			Expect(apierrors.IsNotFound(reconciler.Get(ctx, req.NamespacedName, workload))).To(BeTrue(), kind)
+		}
+		Expect(apierrors.IsNotFound(reconciler.Get(ctx, types.NamespacedName{Name: installJob.Name, Namespace: "default"}, &batchv1.Job{}))).To(BeTrue())
+		Expect(uninstallJob().Spec.Template.Spec.Containers[0].Command).To(ContainElement(ContainSubstring("uninstall default")))
+	})
+})
@@ -0,0 +1,222 @@
+/*
+** Copyright (c) 2024 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	databasev1 "example.com/oracle-ords-operator/api/v1"
+)
+
+var _ = Describe("Workload restart tracking", func() {
+	ctx := context.Background()
+	var reconciler *RestDataServicesReconciler
+	var ords *databasev1.RestDataServices
+
+	deployment := func() *appsv1.Deployment {
+		workload := &appsv1.Deployment{}
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace}, workload)).To(Succeed())
+		return workload
+	}
+
+	BeforeEach(func() {
+		ords = &databasev1.RestDataServices{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", UID: "ords-uid"},
+			Spec:       databasev1.RestDataServicesSpec{Image: "container-registry.oracle.com/database/ords:24.1.0"},
+		}
+		ords.Spec.SetDefaults()
+		reconciler = newFakeReconciler(ords)
+	})
+
+	It("should start new pods with the rendered configuration", func() {
+		restartRequired, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restartRequired).To(BeFalse())
+		Expect(deployment().Annotations).To(HaveKeyWithValue(configHashAnnotation, "hash-1"))
+		Expect(deployment().Spec.Template.Annotations).To(HaveKeyWithValue(configHashAnnotation, "hash-1"))
+	})
+
+	It("should require a restart when the configuration changes", func() {
+		_, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		restartRequired, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restartRequired).To(BeTrue())
+		Expect(deployment().Annotations).To(HaveKeyWithValue(configHashAnnotation, "hash-2"))
+		Expect(deployment().Spec.Template.Annotations).To(HaveKeyWithValue(configHashAnnotation, "hash-1"))
+	})
+
+	It("should restart pods on configuration changes when forceRestart is set", func() {
+		_, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		ords.Spec.ForceRestart = true
+		restartRequired, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restartRequired).To(BeFalse())
+		Expect(deployment().Spec.Template.Annotations).To(HaveKeyWithValue(configHashAnnotation, "hash-2"))
+	})
+
+	It("should pick up the configuration when the pods are restarted anyway", func() {
+		_, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		ords.Spec.Image = "container-registry.oracle.com/database/ords:24.2.0"
+		restartRequired, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restartRequired).To(BeFalse())
+		Expect(deployment().Spec.Template.Annotations).To(HaveKeyWithValue(configHashAnnotation, "hash-2"))
+	})
+
+	It("should pull the image with the policy and Secrets of the spec", func() {
+		ords.Spec.ImagePullPolicy = corev1.PullAlways
+		ords.Spec.ImagePullSecrets = databasev1.ImagePullSecrets{{Name: "registry-auth"}, {Name: "mirror-auth"}}
+		_, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		podSpec := deployment().Spec.Template.Spec
+		Expect(podSpec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "registry-auth"}, {Name: "mirror-auth"}}))
+		Expect(podSpec.InitContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+		Expect(podSpec.Containers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+	})
+
+	It("should merge the podTemplate without overriding the selector labels", func() {
+		ords.Spec.PodTemplate = databasev1.PodTemplate{
+			Labels:             map[string]string{"team": "apis", "app.kubernetes.io/instance": "other"},
+			Annotations:        map[string]string{"prometheus.io/scrape": "true"},
+			Resources:          corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")}},
+			NodeSelector:       map[string]string{"kubernetes.io/os": "linux"},
+			Tolerations:        []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+			PriorityClassName:  "high-priority",
+			ServiceAccountName: "ords",
+		}
+		_, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		template := deployment().Spec.Template
+		Expect(template.Labels).To(HaveKeyWithValue("team", "apis"))
+		Expect(template.Labels).To(HaveKeyWithValue("app.kubernetes.io/instance", ords.Name))
+		Expect(deployment().Spec.Selector.MatchLabels).To(Equal(getLabels(ords.Name)))
+		Expect(template.Annotations).To(HaveKeyWithValue("prometheus.io/scrape", "true"))
+		Expect(template.Annotations).To(HaveKeyWithValue(configHashAnnotation, "hash-1"))
+		Expect(template.Spec.NodeSelector).To(HaveKeyWithValue("kubernetes.io/os", "linux"))
+		Expect(template.Spec.Tolerations).To(HaveLen(1))
+		Expect(template.Spec.PriorityClassName).To(Equal("high-priority"))
+		Expect(template.Spec.ServiceAccountName).To(Equal("ords"))
+		Expect(template.Spec.Containers[0].Resources.Limits.Memory().String()).To(Equal("2Gi"))
+		Expect(template.Spec.InitContainers[0].Resources.Limits.Memory().String()).To(Equal("2Gi"))
+	})
+
+	It("should roll out podTemplate changes", func() {
+		_, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		specHash := deployment().Labels[specHashLabel]
+
+		ords.Spec.PodTemplate.NodeSelector = map[string]string{"topology.kubernetes.io/zone": "zone-a"}
+		_, err = reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deployment().Labels[specHashLabel]).NotTo(Equal(specHash))
+		Expect(deployment().Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("topology.kubernetes.io/zone", "zone-a"))
+	})
+
+	It("should probe the context path over HTTP", func() {
+		container := podTemplateSpecDefine(ords).Spec.Containers[0]
+		for _, probe := range []*corev1.Probe{container.ReadinessProbe, container.LivenessProbe, container.StartupProbe} {
+			Expect(probe.HTTPGet.Path).To(Equal("/ords/"))
+			Expect(probe.HTTPGet.Port).To(Equal(intstr.FromString(targetHTTPPortName)))
+			Expect(probe.HTTPGet.Scheme).To(Equal(corev1.URISchemeHTTP))
+		}
+		Expect(container.StartupProbe.PeriodSeconds * container.StartupProbe.FailureThreshold).To(BeNumerically(">=", 600))
+	})
+
+	It("should probe over HTTPS when it is forced", func() {
+		ords.Spec.GlobalSettings.SecurityForceHTTPS = true
+		ords.Spec.GlobalSettings.StandaloneContextPath = "/api/"
+		ords.Spec.GlobalSettings.SecurityHTTPSHeaderCheck = "X-Forwarded-Proto: https"
+		probe := podTemplateSpecDefine(ords).Spec.Containers[0].ReadinessProbe
+		Expect(probe.HTTPGet.Path).To(Equal("/api/"))
+		Expect(probe.HTTPGet.Port).To(Equal(intstr.FromString(targetHTTPSPortName)))
+		Expect(probe.HTTPGet.Scheme).To(Equal(corev1.URISchemeHTTPS))
+		Expect(probe.HTTPGet.HTTPHeaders).To(Equal([]corev1.HTTPHeader{{Name: "X-Forwarded-Proto", Value: "https"}}))
+	})
+
+	It("should replace a default probe with its override", func() {
+		override := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{
+			Port: intstr.FromString(targetHTTPPortName)}}}
+		ords.Spec.PodTemplate.LivenessProbe = override
+		container := podTemplateSpecDefine(ords).Spec.Containers[0]
+		Expect(container.LivenessProbe).To(Equal(override))
+		Expect(container.ReadinessProbe.HTTPGet).NotTo(BeNil())
+	})
+
+	It("should leave the replicas to the HorizontalPodAutoscaler while autoscaling", func() {
+		ords.Spec.Replicas = 2
+		ords.Spec.Autoscaling = &databasev1.AutoscalingSettings{MaxReplicas: 5}
+		ords.Spec.SetDefaults()
+		_, err := reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*deployment().Spec.Replicas).To(Equal(int32(2)))
+
+		// Scaled by the HorizontalPodAutoscaler
+		workload := deployment()
+		workload.Spec.Replicas = &[]int32{4}[0]
+		Expect(reconciler.Update(ctx, workload)).To(Succeed())
+		ords.Spec.Image = "container-registry.oracle.com/database/ords:24.2.0"
+		_, err = reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*deployment().Spec.Replicas).To(Equal(int32(4)))
+		Expect(deployment().Spec.Template.Spec.Containers[0].Image).To(Equal(ords.Spec.Image))
+
+		ords.Spec.Autoscaling = nil
+		_, err = reconciler.WorkloadReconcile(ctx, ctrl.Request{}, ords, "Deployment", "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*deployment().Spec.Replicas).To(Equal(int32(2)))
+	})
+})
@@ -47,9 +47,12 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -110,3 +113,20 @@ var _ = AfterSuite(func() {
 	err := testEnv.Stop()
 	Expect(err).NotTo(HaveOccurred())
 })
+
+// newFakeReconciler returns a reconciler using a fake client holding the objects, for the specs of a single
+// reconcile step; the client has the field indexes of the manager and the status subresource of the CRD
+func newFakeReconciler(objs ...client.Object) *RestDataServicesReconciler {
+	fakeScheme := k8sruntime.NewScheme()
+	Expect(scheme.AddToScheme(fakeScheme)).To(Succeed())
+	Expect(databasev1.AddToScheme(fakeScheme)).To(Succeed())
+	return &RestDataServicesReconciler{
+		Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(objs...).
+			WithStatusSubresource(&databasev1.RestDataServices{}).
+			WithIndex(&databasev1.RestDataServices{}, secretIndexKey, secretIndexValues).
+			WithIndex(&databasev1.RestDataServices{}, initScriptIndexKey, initScriptIndexValues).
+			Build(),
+		Scheme:   fakeScheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+}